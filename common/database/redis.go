@@ -72,6 +72,18 @@ func (r *RedisManager) Close() {
 	}
 }
 
+// Cmdable 单机、集群统一的命令接口，用于 Pipelined、Eval 等没有单独封装的操作，没有连接时返回 nil
+func (r *RedisManager) Cmdable() redis.Cmdable {
+	if r.ClusterClt != nil {
+		return r.ClusterClt
+	}
+	if r.Clt != nil {
+		return r.Clt
+	}
+	// 直接返回 r.Clt 会得到非 nil 的接口值，调用方判断不出来
+	return nil
+}
+
 func (r *RedisManager) Set(ctx context.Context, key, value string, expiration time.Duration) error {
 	if r.ClusterClt != nil {
 		return r.ClusterClt.Set(ctx, key, value, expiration).Err()
@@ -83,3 +95,40 @@ func (r *RedisManager) Set(ctx context.Context, key, value string, expiration ti
 
 	return nil
 }
+
+func (r *RedisManager) SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	if r.ClusterClt != nil {
+		return r.ClusterClt.SetNX(ctx, key, value, expiration).Result()
+	}
+
+	if r.Clt != nil {
+		return r.Clt.SetNX(ctx, key, value, expiration).Result()
+	}
+
+	return false, nil
+}
+
+// Get key 不存在时返回 redis.Nil
+func (r *RedisManager) Get(ctx context.Context, key string) (string, error) {
+	if r.ClusterClt != nil {
+		return r.ClusterClt.Get(ctx, key).Result()
+	}
+
+	if r.Clt != nil {
+		return r.Clt.Get(ctx, key).Result()
+	}
+
+	return "", redis.Nil
+}
+
+func (r *RedisManager) Del(ctx context.Context, keys ...string) error {
+	if r.ClusterClt != nil {
+		return r.ClusterClt.Del(ctx, keys...).Err()
+	}
+
+	if r.Clt != nil {
+		return r.Clt.Del(ctx, keys...).Err()
+	}
+
+	return nil
+}
@@ -13,6 +13,8 @@ type Server struct {
 	Weight  int    `json:"weight"`
 	Version string `json:"version"`
 	Ttl     int64  `json:"ttl"`
+	// 附加信息，比如 connector 的 serverId 和 tcp 端口
+	Meta map[string]string `json:"meta,omitempty"`
 }
 
 // BuildRegisterKey
@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"common/config"
+	"common/logs"
+	"context"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Lister 按服务名查询 etcd 中注册的服务，不走 grpc 的场景使用，比如 connector 排空时查找其他在线的 connector。
+// 租约过期（服务宕机）或已经注销的服务不在结果中
+type Lister struct {
+	etcdClt   *clientv3.Client
+	rwTimeout int
+}
+
+func NewLister(conf config.EtcdConf) (*Lister, error) {
+	clt, err := clientv3.New(clientv3.Config{
+		Endpoints:   conf.Addrs,
+		DialTimeout: time.Duration(conf.DialTimeout) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rwTimeout := conf.RWTimeout
+	if rwTimeout <= 0 {
+		rwTimeout = 3
+	}
+	return &Lister{
+		etcdClt:   clt,
+		rwTimeout: rwTimeout,
+	}, nil
+}
+
+// List 查询名为 name 的所有服务，包括各个版本
+func (l *Lister) List(name string) ([]Server, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(l.rwTimeout)*time.Second)
+	defer cancel()
+	// key 形式：/name/addr 或 /name/version/addr
+	resp, err := l.etcdClt.Get(ctx, "/"+name+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]Server, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		server, err := ParseValue(kv.Value)
+		if err != nil {
+			logs.Error("parse etcd value failed: key=%s, err=%v", kv.Key, err)
+			continue
+		}
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
+
+func (l *Lister) Close() error {
+	return l.etcdClt.Close()
+}
@@ -40,6 +40,11 @@ func (r *Register) Register(conf config.EtcdConf) error {
 		Version: conf.Register.Version,
 		Ttl:     conf.Register.Ttl,
 	}
+	return r.RegisterServer(conf, info)
+}
+
+// RegisterServer 注册信息不从 conf.Register 读取时使用，比如 connector 注册自己的客户端地址
+func (r *Register) RegisterServer(conf config.EtcdConf, info Server) error {
 	// 建立 etcd 连接
 	var err error
 	r.etcdClt, err = clientv3.New(clientv3.Config{
@@ -0,0 +1,34 @@
+package metrics
+
+import "expvar"
+
+// connector 写客户端的统计，通过 /debug/vars 查看
+var (
+	writeDropped   = expvar.NewInt("write_dropped")   // 写队列满时丢弃的推送数，响应不会被丢弃
+	slowDisconnect = expvar.NewInt("slow_disconnect") // 写队列满被断开的连接数
+	writeFail      = expvar.NewInt("write_fail")      // 写超时或写失败断开的连接数
+	rateLimited    = expvar.NewMap("rate_limited")    // 路由 -> 被限流的包数，非 Data 包记为 packet
+)
+
+// ObserveWriteDrop 记录一条因写队列满被丢弃的推送
+func ObserveWriteDrop() {
+	writeDropped.Add(1)
+}
+
+// ObserveSlowDisconnect 记录一个因消费太慢被断开的连接
+func ObserveSlowDisconnect() {
+	slowDisconnect.Add(1)
+}
+
+// ObserveWriteFail 记录一个因写超时或写失败被断开的连接
+func ObserveWriteFail() {
+	writeFail.Add(1)
+}
+
+// ObserveRateLimit 记录一个被限流的包
+func ObserveRateLimit(route string) {
+	if route == "" {
+		route = "packet"
+	}
+	rateLimited.Add(route, 1)
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"expvar"
+	"time"
+)
+
+// handler 调用统计，通过 /debug/vars 查看
+var (
+	handlerCount   = expvar.NewMap("handler_count")      // 路由 -> 调用次数
+	handlerFail    = expvar.NewMap("handler_fail")       // 路由 -> 失败（panic/返回 error）次数
+	handlerLatency = expvar.NewMap("handler_latency_ms") // 路由 -> 累计耗时（毫秒）
+)
+
+// ObserveHandler 记录一次 handler 调用
+func ObserveHandler(route string, cost time.Duration, failed bool) {
+	handlerCount.Add(route, 1)
+	handlerLatency.Add(route, cost.Milliseconds())
+	if failed {
+		handlerFail.Add(route, 1)
+	}
+}
@@ -1,17 +1,26 @@
 package metrics
 
 import (
+	"expvar"
 	"net/http"
 
 	"github.com/arl/statsviz"
 )
 
-// Serve 统计可视化实时监控 端点 /debug/statsviz
+// 监控端口只在内网开放，管理接口也注册在这里
+var mux = http.NewServeMux()
+
+// Handle 在监控端口上注册管理接口，比如 connector 的 /admin/drain
+func Handle(pattern string, handler http.Handler) {
+	mux.Handle(pattern, handler)
+}
+
+// Serve 统计可视化实时监控 端点 /debug/statsviz，handler 调用统计 端点 /debug/vars
 func Serve(addr string) error {
-	mux := http.NewServeMux()
 	if err := statsviz.Register(mux); err != nil {
 		return err
 	}
+	mux.Handle("/debug/vars", expvar.Handler())
 
 	if err := http.ListenAndServe(addr, mux); err != nil {
 		return err
@@ -13,6 +13,11 @@ type Result struct {
 	Msg  any `json:"msg"`
 }
 
+// Failed code 不为 OK 时是业务失败，node.Metrics 据此统计失败次数
+func (r Result) Failed() bool {
+	return r.Code != biz.OK
+}
+
 func Success(ctx *gin.Context, data any) {
 	ctx.JSON(http.StatusOK, Result{Code: biz.OK, Msg: data})
 }
@@ -3,11 +3,15 @@ package app
 import (
 	"common/config"
 	"common/logs"
+	"common/metrics"
 	"connector/route"
 	"context"
 	"core/repo"
 	"fmt"
 	"framework/connector"
+	"framework/directory"
+	"framework/net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -19,14 +23,24 @@ func Run(ctx context.Context, serverId string) error {
 	// 初始化日志
 	logs.InitLog(config.Conf.AppName)
 	exit := func() {}
+	drain := func() {}
 	go func() {
 		// 创建/启动两个组件：1.websocketmanager 2.natsClient
 		c := connector.Default()
 		exit = c.Close
+		drain = c.Drain
 		// 初始化数据库
 		manager := repo.New()
-		// 注册路由
+		// 注册路由，所有路由都经过 panic 恢复、统计和日志
+		c.Use(net.Recover(), net.Metrics(), net.Logger())
 		c.RegisterHandler(route.Register(manager))
+		c.RegisterRoomRoute(directory.NewRedisRoomDirectory(manager.Redis), route.RoomRoutes())
+		c.RegisterRoutes(route.Routes()...)
+		c.AllowAnonymous(route.AnonymousRoutes()...)
+		c.SetPresence(directory.NewRedisPresence(manager.Redis))
+		if len(config.Conf.Etcd.Addrs) > 0 {
+			c.SetDiscovery(config.Conf.Etcd)
+		}
 		c.Run(serverId)
 	}()
 	// 优雅启停 遇到：中断 退出 中止 挂断信号 先执行清理操作，再退出
@@ -37,6 +51,19 @@ func Run(ctx context.Context, serverId string) error {
 		time.Sleep(3 * time.Second)
 		fmt.Println("stop app finish")
 	}
+	// 管理命令：POST /admin/drain（监控端口）排空后退出，和 SIGTERM 一样
+	drainCh := make(chan struct{}, 1)
+	metrics.Handle("/admin/drain", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		select {
+		case drainCh <- struct{}{}:
+		default:
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT, syscall.SIGHUP)
 	for {
@@ -44,9 +71,18 @@ func Run(ctx context.Context, serverId string) error {
 		case <-ctx.Done():
 			// timeout
 			stop()
+		case <-drainCh:
+			drain()
+			logs.Info("connector app drained, quit")
+			return nil
 		case s := <-c:
 			switch s {
-			case syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT:
+			case syscall.SIGTERM:
+				// 滚动发布：排空后退出，客户端重连到其他 connector
+				drain()
+				logs.Info("connector app drained, quit")
+				return nil
+			case syscall.SIGQUIT, syscall.SIGINT:
 				// 中止/退出/中断 信号
 				stop()
 				logs.Info("connector app quit")
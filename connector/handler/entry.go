@@ -38,7 +38,7 @@ func (h EntryHandler) Entry(session *net.Session, body []byte) (any, error) {
 		return common.FailNoCtx(biz.SqlError), nil
 	}
 	// 保存用户 uid 到 session
-	session.Uid = uid
+	session.Bind(uid)
 	return common.SuccessNoCtx(map[string]any{
 		"userInfo": user,
 		"config":   game.Conf.GetFrontGameConfig(),
@@ -4,6 +4,7 @@ import (
 	"connector/handler"
 	"core/repo"
 	"framework/net"
+	"framework/protocol"
 )
 
 func Register(r *repo.Manager) net.LogicHandler {
@@ -12,3 +13,33 @@ func Register(r *repo.Manager) net.LogicHandler {
 	handlers["entryHandler.entry"] = entryHandler.Entry
 	return handlers
 }
+
+// RoomRoutes 房间只存在于创建它的 game 节点，这些请求需要发往房间所在的节点
+func RoomRoutes() net.RoomRoutes {
+	routes := make(net.RoomRoutes)
+	routes["game.unionHandler.joinRoom"] = net.BodyRoomId("roomID")
+	routes["game.gameHandler.roomMessageNotify"] = net.SessionRoomId("roomId")
+	routes["game.gameHandler.gameMessageNotify"] = net.SessionRoomId("roomId")
+	return routes
+}
+
+// AnonymousRoutes 登录之前可以访问的路由，其他路由 connector 会拒绝
+func AnonymousRoutes() []string {
+	return []string{
+		"connector.entryHandler.entry",
+	}
+}
+
+// Routes 后端的 handler 路由和推送路由，connector 用它们生成路由字典，握手时下发给客户端。
+// route_test 会检查它和 hall、game 注册的 handler 一致
+func Routes() []string {
+	return []string{
+		"hall.userHandler.updateUserAddress",
+		"game.unionHandler.createRoom",
+		"game.unionHandler.joinRoom",
+		"game.gameHandler.roomMessageNotify",
+		"game.gameHandler.gameMessageNotify",
+		// 推送
+		protocol.ServerMessagePush,
+	}
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"core/repo"
+	"framework/protocol"
+	gameRoute "game/route"
+	hallRoute "hall/route"
+	"testing"
+)
+
+// 路由字典是手动维护的，后端新增 handler 或推送时需要同步加到 Routes
+func TestRoutesCoverRegistered(t *testing.T) {
+	r := &repo.Manager{}
+	registered := map[string]bool{}
+	for route := range hallRoute.Register(r) {
+		registered["hall."+route] = true
+	}
+	for route := range gameRoute.Register(r, "game-test") {
+		registered["game."+route] = true
+	}
+	dict := map[string]bool{}
+	for _, route := range Routes() {
+		dict[route] = true
+	}
+	for route := range registered {
+		if !dict[route] {
+			t.Errorf("registered route %s missing in Routes()", route)
+		}
+	}
+	for route := range RoomRoutes() {
+		if !registered[route] {
+			t.Errorf("room route %s is not registered", route)
+		}
+	}
+	pushes := map[string]bool{protocol.ServerMessagePush: true}
+	for push := range pushes {
+		if !dict[push] {
+			t.Errorf("push route %s missing in Routes()", push)
+		}
+	}
+	for route := range dict {
+		if !registered[route] && !pushes[route] {
+			t.Errorf("route %s in Routes() is neither registered nor a push route", route)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// 负载均衡：connector 选择转发请求的后端节点，节点之间的同步调用选择被调用的节点
+package balancer
+
+import (
+	"framework/game"
+	"framework/msError"
+	"hash/crc32"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 负载均衡策略，对应 ConnectorConfig.Balancer
+const (
+	RandomBalance     = "random"
+	RoundRobinBalance = "roundRobin"
+	WeightedBalance   = "weighted"
+	HashBalance       = "hash"
+)
+
+// Session 负载均衡需要的会话信息
+type Session interface {
+	// HashKey 一致性哈希使用的 key，一般是 uid，未登录时用 cid
+	HashKey() string
+	// GetServer、BindServer 粘性路由时读取、记录 serverType 绑定的 serverId
+	GetServer(serverType string) (string, bool)
+	BindServer(serverType, serverId string)
+}
+
+// Balancer 从 serverType 对应的服务器列表中选出目标 serverId
+type Balancer interface {
+	Select(session Session, serverType string, servers []*game.ServersConfig) (string, error)
+}
+
+func New(conf *game.ConnectorConfig) Balancer {
+	var b Balancer
+	name := ""
+	if conf != nil {
+		name = conf.Balancer
+	}
+	switch name {
+	case RoundRobinBalance:
+		b = NewRoundRobinBalancer()
+	case WeightedBalance:
+		b = NewWeightedBalancer()
+	case HashBalance:
+		b = NewHashBalancer()
+	default:
+		b = &RandomBalancer{}
+	}
+	if conf != nil && conf.Sticky {
+		b = NewStickyBalancer(b)
+	}
+	return b
+}
+
+// RandomBalancer 随机选一个服务器
+type RandomBalancer struct{}
+
+func (b *RandomBalancer) Select(session Session, serverType string, servers []*game.ServersConfig) (string, error) {
+	if len(servers) == 0 {
+		return "", msError.NoServerFound
+	}
+	return servers[rand.Intn(len(servers))].ID, nil
+}
+
+// RoundRobinBalancer 按 serverType 轮询
+type RoundRobinBalancer struct {
+	sync.Mutex
+	counters map[string]*uint64
+}
+
+func (b *RoundRobinBalancer) Select(session Session, serverType string, servers []*game.ServersConfig) (string, error) {
+	if len(servers) == 0 {
+		return "", msError.NoServerFound
+	}
+	b.Lock()
+	counter, ok := b.counters[serverType]
+	if !ok {
+		counter = new(uint64)
+		b.counters[serverType] = counter
+	}
+	b.Unlock()
+	n := atomic.AddUint64(counter, 1) - 1
+	return servers[n%uint64(len(servers))].ID, nil
+}
+
+func NewRoundRobinBalancer() *RoundRobinBalancer {
+	return &RoundRobinBalancer{
+		counters: make(map[string]*uint64),
+	}
+}
+
+// WeightedBalancer 平滑加权轮询（nginx 算法），权重取 ServersConfig.Weight，未配置按 1 处理
+type WeightedBalancer struct {
+	sync.Mutex
+	current map[string]map[string]int // serverType -> serverId -> currentWeight
+}
+
+func (b *WeightedBalancer) Select(session Session, serverType string, servers []*game.ServersConfig) (string, error) {
+	if len(servers) == 0 {
+		return "", msError.NoServerFound
+	}
+	b.Lock()
+	defer b.Unlock()
+	current, ok := b.current[serverType]
+	if !ok {
+		current = make(map[string]int)
+		b.current[serverType] = current
+	}
+	total := 0
+	var best *game.ServersConfig
+	for _, s := range servers {
+		w := weightOf(s)
+		total += w
+		current[s.ID] += w
+		if best == nil || current[s.ID] > current[best.ID] {
+			best = s
+		}
+	}
+	current[best.ID] -= total
+	return best.ID, nil
+}
+
+func NewWeightedBalancer() *WeightedBalancer {
+	return &WeightedBalancer{
+		current: make(map[string]map[string]int),
+	}
+}
+
+func weightOf(s *game.ServersConfig) int {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+// HashBalancer 按 uid 一致性哈希，未登录时用 cid；服务器增减只影响环上相邻的一部分用户
+type HashBalancer struct {
+	sync.RWMutex
+	rings map[string]*hashRing // serverType -> ring
+}
+
+// 每个服务器在环上的虚拟节点数（按权重倍增），让分布更均匀
+const virtualNodes = 100
+
+type hashRing struct {
+	key    string // 服务器列表签名，配置变更后重建
+	hashes []uint32
+	nodes  map[uint32]string
+}
+
+func (b *HashBalancer) Select(session Session, serverType string, servers []*game.ServersConfig) (string, error) {
+	if len(servers) == 0 {
+		return "", msError.NoServerFound
+	}
+	ring := b.ring(serverType, servers)
+	h := crc32.ChecksumIEEE([]byte(session.HashKey()))
+	i := sort.Search(len(ring.hashes), func(i int) bool {
+		return ring.hashes[i] >= h
+	})
+	if i == len(ring.hashes) {
+		i = 0
+	}
+	return ring.nodes[ring.hashes[i]], nil
+}
+
+func (b *HashBalancer) ring(serverType string, servers []*game.ServersConfig) *hashRing {
+	ids := make([]string, 0, len(servers))
+	for _, s := range servers {
+		ids = append(ids, s.ID+":"+strconv.Itoa(weightOf(s)))
+	}
+	key := strings.Join(ids, ",")
+	b.RLock()
+	ring, ok := b.rings[serverType]
+	b.RUnlock()
+	if ok && ring.key == key {
+		return ring
+	}
+	ring = &hashRing{
+		key:   key,
+		nodes: make(map[uint32]string),
+	}
+	for _, s := range servers {
+		for i := 0; i < virtualNodes*weightOf(s); i++ {
+			h := crc32.ChecksumIEEE([]byte(s.ID + "#" + strconv.Itoa(i)))
+			ring.hashes = append(ring.hashes, h)
+			ring.nodes[h] = s.ID
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool {
+		return ring.hashes[i] < ring.hashes[j]
+	})
+	b.Lock()
+	b.rings[serverType] = ring
+	b.Unlock()
+	return ring
+}
+
+func NewHashBalancer() *HashBalancer {
+	return &HashBalancer{
+		rings: make(map[string]*hashRing),
+	}
+}
+
+// StickyBalancer 第一次由 next 选出服务器并绑定到 session，之后一直发往该服务器，直到它从配置中移除
+type StickyBalancer struct {
+	next Balancer
+}
+
+func (b *StickyBalancer) Select(session Session, serverType string, servers []*game.ServersConfig) (string, error) {
+	if serverId, ok := session.GetServer(serverType); ok {
+		for _, s := range servers {
+			if s.ID == serverId {
+				return serverId, nil
+			}
+		}
+	}
+	serverId, err := b.next.Select(session, serverType, servers)
+	if err != nil {
+		return "", err
+	}
+	session.BindServer(serverType, serverId)
+	return serverId, nil
+}
+
+func NewStickyBalancer(next Balancer) *StickyBalancer {
+	return &StickyBalancer{
+		next: next,
+	}
+}
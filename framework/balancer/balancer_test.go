@@ -0,0 +1,181 @@
+package balancer
+
+import (
+	"errors"
+	"framework/game"
+	"framework/msError"
+	"strconv"
+	"testing"
+)
+
+// 测试用会话
+type testSession struct {
+	key     string
+	servers map[string]string
+}
+
+func newTestSession(key string) *testSession {
+	return &testSession{key: key, servers: make(map[string]string)}
+}
+
+func (s *testSession) HashKey() string {
+	return s.key
+}
+
+func (s *testSession) GetServer(serverType string) (string, bool) {
+	serverId, ok := s.servers[serverType]
+	return serverId, ok
+}
+
+func (s *testSession) BindServer(serverType, serverId string) {
+	s.servers[serverType] = serverId
+}
+
+func testServers(weights ...int) []*game.ServersConfig {
+	servers := make([]*game.ServersConfig, 0, len(weights))
+	for i, w := range weights {
+		servers = append(servers, &game.ServersConfig{ID: "game-" + strconv.Itoa(i+1), ServerType: "game", Weight: w})
+	}
+	return servers
+}
+
+func TestBalancerNoServer(t *testing.T) {
+	for _, name := range []string{RandomBalance, RoundRobinBalance, WeightedBalance, HashBalance} {
+		t.Run(name, func(t *testing.T) {
+			b := New(&game.ConnectorConfig{Balancer: name, Sticky: true})
+			if _, err := b.Select(newTestSession("cid"), "game", nil); !errors.Is(err, msError.NoServerFound) {
+				t.Fatalf("expected NoServerFound, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBalancerDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       Balancer
+		weights []int
+		rounds  int
+		want    map[string]int
+	}{
+		{"roundRobin", NewRoundRobinBalancer(), []int{0, 0, 0}, 30, map[string]int{"game-1": 10, "game-2": 10, "game-3": 10}},
+		// 未配置权重按 1 处理
+		{"weighted", NewWeightedBalancer(), []int{5, 1, 0}, 70, map[string]int{"game-1": 50, "game-2": 10, "game-3": 10}},
+		{"weightedEqual", NewWeightedBalancer(), []int{2, 2}, 10, map[string]int{"game-1": 5, "game-2": 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers := testServers(tt.weights...)
+			got := make(map[string]int)
+			for i := 0; i < tt.rounds; i++ {
+				id, err := tt.b.Select(newTestSession("cid"), "game", servers)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[id]++
+			}
+			for id, n := range tt.want {
+				if got[id] != n {
+					t.Fatalf("unexpected distribution: %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomBalancer(t *testing.T) {
+	servers := testServers(1, 1, 1)
+	b := &RandomBalancer{}
+	for i := 0; i < 100; i++ {
+		id, err := b.Select(newTestSession("cid"), "game", servers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "game-1" && id != "game-2" && id != "game-3" {
+			t.Fatalf("unexpected server: %s", id)
+		}
+	}
+}
+
+// 按 uid 哈希：增减服务器时只有落在变化节点上的用户会迁移
+func TestHashBalancerStability(t *testing.T) {
+	selectAll := func(b Balancer, servers []*game.ServersConfig) map[string]string {
+		result := make(map[string]string)
+		for i := 0; i < 1000; i++ {
+			session := newTestSession(strconv.Itoa(100000 + i))
+			id, err := b.Select(session, "game", servers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result[session.key] = id
+		}
+		return result
+	}
+	b := NewHashBalancer()
+	before := selectAll(b, testServers(1, 1, 1))
+	again := selectAll(b, testServers(1, 1, 1))
+	for uid, id := range before {
+		if again[uid] != id {
+			t.Fatalf("uid %s moved without topology change", uid)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		servers []*game.ServersConfig
+		changed string // 允许迁入或迁出的服务器
+	}{
+		{"add", testServers(1, 1, 1, 1), "game-4"},
+		{"remove", testServers(1, 1), "game-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := selectAll(b, tt.servers)
+			moved := 0
+			for uid, id := range before {
+				if after[uid] == id {
+					continue
+				}
+				moved++
+				if after[uid] != tt.changed && id != tt.changed {
+					t.Fatalf("uid %s moved from %s to %s", uid, id, after[uid])
+				}
+			}
+			if moved == 0 || moved > len(before)/2 {
+				t.Fatalf("unexpected moved count: %d", moved)
+			}
+		})
+	}
+}
+
+func TestStickyBalancer(t *testing.T) {
+	b := NewStickyBalancer(NewRoundRobinBalancer())
+	session := newTestSession("cid")
+	servers := testServers(1, 1, 1)
+	first, err := b.Select(session, "game", servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if id, _ := b.Select(session, "game", servers); id != first {
+			t.Fatalf("sticky server changed: %s -> %s", first, id)
+		}
+	}
+	// 绑定的服务器从配置中移除后，重新选择并绑定
+	var rest []*game.ServersConfig
+	for _, s := range servers {
+		if s.ID != first {
+			rest = append(rest, s)
+		}
+	}
+	next, err := b.Select(session, "game", rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == first {
+		t.Fatalf("removed server %s still selected", first)
+	}
+	if bound, ok := session.GetServer("game"); !ok || bound != next {
+		t.Fatalf("session bound to %s, want %s", bound, next)
+	}
+}
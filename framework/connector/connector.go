@@ -1,11 +1,20 @@
 package connector
 
 import (
+	"common/config"
+	"common/discovery"
 	"common/logs"
+	"context"
 	"fmt"
+	"framework/balancer"
+	"framework/directory"
 	"framework/game"
 	"framework/net"
+	"framework/protocol"
 	"framework/remote"
+	stdnet "net"
+	"sync"
+	"time"
 )
 
 type Connector struct {
@@ -13,11 +22,30 @@ type Connector struct {
 	wsManager *net.WsManager
 	handlers  net.LogicHandler
 	remoteClt remote.Client
+	newRemote remote.ClientFactory // 默认 NATS，单进程部署/测试时可换成 MemoryBus
+	roomDir   directory.RoomDirectory
+	roomRoute net.RoomRoutes
+	presence  directory.Presence
+	routes    []string // 后端路由和推送路由，加入路由字典
+	// 不需要登录就能访问的路由
+	anonymousRoutes []string
+	// 作用于所有本地路由的 middleware
+	middlewares []net.Middleware
+	// 服务发现：启动时注册客户端地址，排空时从中查找其他在线的 connector
+	etcdConf       *config.EtcdConf
+	register       *discovery.Register
+	lister         *discovery.Lister
+	unregisterOnce sync.Once
 }
 
+// connector 注册到 etcd 的租约时间（秒），宕机后最多这么久不再被选为重连目标
+const registerTtl = 10
+
 func Default() *Connector {
 	return &Connector{
-		handlers: make(net.LogicHandler),
+		handlers:  make(net.LogicHandler),
+		roomRoute: make(net.RoomRoutes),
+		newRemote: remote.NatsClientFactory,
 	}
 }
 
@@ -25,10 +53,39 @@ func Default() *Connector {
 func (c *Connector) Run(serverId string) {
 	if !c.isRunning {
 		c.wsManager = net.NewWsManager()
+		for route, h := range c.handlers {
+			c.handlers[route] = net.Chain(route, h, c.middlewares...)
+		}
 		c.wsManager.ConnectorHandlers = c.handlers
 		c.wsManager.ServerId = serverId
+		c.wsManager.RoomDirectory = c.roomDir
+		c.wsManager.RoomRoutes = c.roomRoute
+		c.wsManager.Presence = c.presence
+		c.wsManager.Routes = c.routes
+		c.wsManager.AnonymousRoutes = c.anonymousRoutes
+		connectorConfig := game.Conf.GetConnector(serverId)
+		c.wsManager.Balancer = balancer.New(connectorConfig)
+		if connectorConfig != nil {
+			c.wsManager.WorkerNum = connectorConfig.WorkerNum
+			c.wsManager.WorkerQueueSize = connectorConfig.WorkerQueueSize
+			c.wsManager.Sys = protocol.Sys{
+				Heartbeat:    connectorConfig.HeartTime,
+				ProtoVersion: connectorConfig.ProtoVersion,
+				Version:      connectorConfig.Version,
+				Dict:         connectorConfig.Dict,
+			}
+			c.wsManager.HeartbeatMiss = connectorConfig.HeartbeatMiss
+			c.wsManager.CompressThreshold = connectorConfig.CompressThreshold
+			c.wsManager.CertFile = connectorConfig.CertFile
+			c.wsManager.KeyFile = connectorConfig.KeyFile
+			c.wsManager.WriteQueueSize = connectorConfig.WriteQueueSize
+			c.wsManager.WritePolicy = connectorConfig.WritePolicy
+			c.wsManager.WriteTimeout = time.Duration(connectorConfig.WriteTimeout) * time.Second
+			c.wsManager.RateLimit = connectorConfig.RateLimit
+			c.wsManager.AuthTimeout = time.Duration(connectorConfig.AuthTimeout) * time.Second
+		}
 		// 启动 nat nats，不会像 kafka 一样存储消息，如果没有推送的地方，消息就直接丢失
-		c.remoteClt = remote.NewNatsClient(serverId, c.wsManager.RemoteReadChan)
+		c.remoteClt = c.newRemote(serverId, c.wsManager.RemoteReadChan)
 		c.remoteClt.Run()
 		c.wsManager.RemoteClt = c.remoteClt
 		// 启动 websocket
@@ -38,11 +95,44 @@ func (c *Connector) Run(serverId string) {
 
 func (c *Connector) Close() {
 	if c.isRunning {
+		c.unregister()
 		// 关闭 websocket 和 nats
 		c.wsManager.Close()
+		if c.lister != nil {
+			_ = c.lister.Close()
+		}
 	}
 }
 
+// 从服务发现中注销，其他 connector 排空时不会再把客户端引导过来
+func (c *Connector) unregister() {
+	c.unregisterOnce.Do(func() {
+		if c.register != nil {
+			c.register.Close()
+		}
+	})
+}
+
+// 没有配置 drainTimeout 时，排空最多等待客户端重连的时间
+const defaultDrainTimeout = 60 * time.Second
+
+// Drain 排空后关闭：不再接受新连接，通知客户端连接其他 connector，等客户端断开和正在处理的请求完成，
+// 滚动发布时不会直接断掉进行中的牌局
+func (c *Connector) Drain() {
+	if !c.isRunning {
+		return
+	}
+	timeout := defaultDrainTimeout
+	if conf := game.Conf.GetConnector(c.wsManager.ServerId); conf != nil && conf.DrainTimeout > 0 {
+		timeout = time.Duration(conf.DrainTimeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	c.unregister()
+	c.wsManager.Drain(ctx)
+	c.Close()
+}
+
 // 启动 websocket 和 nats
 func (c *Connector) Serve(serverId string) {
 	logs.Info("run connector serverID=%v", serverId)
@@ -52,9 +142,85 @@ func (c *Connector) Serve(serverId string) {
 	}
 	addr := fmt.Sprintf("%s:%d", connectorConfig.Host, connectorConfig.ClientPort)
 	c.isRunning = true
-	c.wsManager.Run(addr)
+	c.wsManager.Start()
+	c.registerDiscovery(serverId, connectorConfig)
+	if connectorConfig.TcpPort > 0 {
+		// 原生客户端走 TCP，和 websocket 共用同一套处理流程
+		tcpAddr := fmt.Sprintf("%s:%d", connectorConfig.Host, connectorConfig.TcpPort)
+		listener, err := stdnet.Listen("tcp", tcpAddr)
+		if err != nil {
+			logs.Fatal("connector tcp listen err:%v", err)
+		}
+		go func() {
+			if err := c.wsManager.ServeTCP(listener); err != nil {
+				logs.Fatal("connector tcp serve err:%v", err)
+			}
+		}()
+	}
+	c.wsManager.ServeWS(addr)
+}
+
+// 把客户端连接的地址注册到 etcd，并用 etcd 查询其他在线的 connector
+func (c *Connector) registerDiscovery(serverId string, conf *game.ConnectorConfig) {
+	if c.etcdConf == nil {
+		return
+	}
+	lister, err := discovery.NewLister(*c.etcdConf)
+	if err != nil {
+		logs.Error("connector discovery connect etcd err: %v", err)
+		return
+	}
+	c.lister = lister
+	c.wsManager.Discovery = lister
+	if conf.ClientHost == "" {
+		logs.Warn("connector %s has no clientHost, not registered to discovery", serverId)
+		return
+	}
+	c.register = discovery.NewRegister()
+	info := net.ConnectorServer(serverId, conf.ClientHost, conf.ClientPort, conf.TcpPort, registerTtl)
+	if err := c.register.RegisterServer(*c.etcdConf, info); err != nil {
+		logs.Error("connector register etcd err: %v", err)
+		c.register = nil
+	}
+}
+
+// SetDiscovery 启用 etcd 服务发现，需要在 Run 之前调用：启动时注册客户端地址（clientHost），
+// 排空时先注销，再从 etcd 中选择其他在线的 connector 让客户端重连
+func (c *Connector) SetDiscovery(conf config.EtcdConf) {
+	c.etcdConf = &conf
 }
 
 func (c *Connector) RegisterHandler(handlers net.LogicHandler) {
 	c.handlers = handlers
 }
+
+// SetPresence 设置用户在线目录，多个 connector 时后端据此把推送发到用户所在的 connector
+func (c *Connector) SetPresence(presence directory.Presence) {
+	c.presence = presence
+}
+
+// SetRemoteClient 替换 remote.Client 的实现，需要在 Run 之前调用
+func (c *Connector) SetRemoteClient(factory remote.ClientFactory) {
+	c.newRemote = factory
+}
+
+// Use 添加作用于所有本地路由的 middleware，在 Run 时包装到每个 handler 外层
+func (c *Connector) Use(middlewares ...net.Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// RegisterRoutes 注册后端 handler 路由和推送路由，握手时它们的 code 随路由字典下发给客户端，传输时用 2 字节 code 代替路由字符串
+func (c *Connector) RegisterRoutes(routes ...string) {
+	c.routes = append(c.routes, routes...)
+}
+
+// AllowAnonymous 登录（绑定 uid）之前允许访问的完整路由，如 connector.entryHandler.entry，其他路由在登录前都返回 Unauthorized
+func (c *Connector) AllowAnonymous(routes ...string) {
+	c.anonymousRoutes = append(c.anonymousRoutes, routes...)
+}
+
+// 房间相关路由发往房间所在的 game 节点
+func (c *Connector) RegisterRoomRoute(dir directory.RoomDirectory, routes net.RoomRoutes) {
+	c.roomDir = dir
+	c.roomRoute = routes
+}
@@ -0,0 +1,116 @@
+package directory
+
+import (
+	"common/database"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const presenceKeyPrefix = "MSQP:Presence:"
+
+// PresenceTTL 在线记录的过期时间，connector 每隔 PresenceTTL/3 调用 Refresh 续期，connector 宕机后记录自动失效
+const PresenceTTL = 90 * time.Second
+
+// 只删除仍然指向本节点（ARGV[1]）的记录：用户已经在其他 connector 重新登录、房间归属过期后被其他节点占用时不能删掉
+var offlineScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// 只续期仍然指向本节点（ARGV[1]）的记录，记录丢失（redis 重启等）时重新登记，已经被其他节点占用时不处理
+var refreshScript = redis.NewScript(`
+local cur = redis.call("GET", KEYS[1])
+if cur == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if not cur then
+	return redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+end
+return 0
+`)
+
+// Presence 用户在线目录：uid -> 用户连接所在的 connector serverId，多个 connector 时用于推送寻址
+type Presence interface {
+	// Online 登记用户所在的 connector，返回之前登记的 connector（用户在其他地方登录着），没有时返回空
+	Online(uid, connectorId string) (string, error)
+	Offline(uid, connectorId string) error
+	// Refresh 批量续期本 connector 上在线用户的记录
+	Refresh(uids []string, connectorId string) error
+	// Lookup 批量查询用户所在的 connector，不在线的用户不在返回结果中
+	Lookup(uids []string) (map[string]string, error)
+}
+
+type RedisPresence struct {
+	redis *database.RedisManager
+}
+
+func (p *RedisPresence) Online(uid, connectorId string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	// SET ... GET 原子地替换并取回旧值
+	prev, err := p.redis.Cmdable().SetArgs(ctx, presenceKeyPrefix+uid, connectorId, redis.SetArgs{Get: true, TTL: PresenceTTL}).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return prev, err
+}
+
+func (p *RedisPresence) Offline(uid, connectorId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return offlineScript.Run(ctx, p.redis.Cmdable(), []string{presenceKeyPrefix + uid}, connectorId).Err()
+}
+
+func (p *RedisPresence) Refresh(uids []string, connectorId string) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	ttl := PresenceTTL.Milliseconds()
+	// pipeline 中用 EVAL 而不是 EVALSHA，避免脚本没有缓存时整批失败
+	_, err := p.redis.Cmdable().Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, uid := range uids {
+			refreshScript.Eval(ctx, pipe, []string{presenceKeyPrefix + uid}, connectorId, ttl)
+		}
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+func (p *RedisPresence) Lookup(uids []string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	cmds := make([]*redis.StringCmd, len(uids))
+	// pipeline 一次往返查询所有用户，集群模式下 go-redis 会按 slot 拆分
+	_, err := p.redis.Cmdable().Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, uid := range uids {
+			cmds[i] = pipe.Get(ctx, presenceKeyPrefix+uid)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	res := make(map[string]string, len(uids))
+	for i, cmd := range cmds {
+		if connectorId, err := cmd.Result(); err == nil {
+			res[uids[i]] = connectorId
+		}
+	}
+	return res, nil
+}
+
+func NewRedisPresence(r *database.RedisManager) *RedisPresence {
+	return &RedisPresence{
+		redis: r,
+	}
+}
@@ -0,0 +1,77 @@
+// 房间目录：房间只存在于创建它的 game 节点内存中（UnionManager），多个 game 节点时需要一个共享的
+// roomId -> serverId 映射，connector 根据它把房间相关的请求路由到房间所在的节点
+package directory
+
+import (
+	"common/database"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const roomKeyPrefix = "MSQP:Room:"
+
+var opTimeout = 3 * time.Second
+
+// RoomTTL 房间归属的过期时间，所在节点每隔 RoomTTL/3 调用 Refresh 续期，节点宕机后归属自动失效
+const RoomTTL = 60 * time.Second
+
+type RoomDirectory interface {
+	// Bind 记录房间归属，roomId 已被其他节点占用时返回 false
+	Bind(roomId, serverId string) (bool, error)
+	// Unbind 解除房间归属，归属已经被其他节点占用时不处理
+	Unbind(roomId, serverId string) error
+	// Refresh 续期房间归属，归属已经过期时重新绑定，已经被其他节点占用时不处理
+	Refresh(roomId, serverId string) error
+	// Lookup 查询房间所在的 serverId，不存在时返回 false
+	Lookup(roomId string) (string, bool, error)
+}
+
+type RedisRoomDirectory struct {
+	redis *database.RedisManager
+}
+
+func (d *RedisRoomDirectory) Bind(roomId, serverId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	// SetNX 保证多个 game 节点生成相同 roomId 时只有一个能占用
+	return d.redis.SetNX(ctx, roomKeyPrefix+roomId, serverId, RoomTTL)
+}
+
+func (d *RedisRoomDirectory) Refresh(roomId, serverId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	// 和在线记录一样比较后再续期，归属过期后被其他节点占用时不能把它续上
+	err := refreshScript.Run(ctx, d.redis.Cmdable(), []string{roomKeyPrefix + roomId}, serverId, RoomTTL.Milliseconds()).Err()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+func (d *RedisRoomDirectory) Unbind(roomId, serverId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	return offlineScript.Run(ctx, d.redis.Cmdable(), []string{roomKeyPrefix + roomId}, serverId).Err()
+}
+
+func (d *RedisRoomDirectory) Lookup(roomId string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	serverId, err := d.redis.Get(ctx, roomKeyPrefix+roomId)
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return serverId, true, nil
+}
+
+func NewRedisRoomDirectory(r *database.RedisManager) *RedisRoomDirectory {
+	return &RedisRoomDirectory{
+		redis: r,
+	}
+}
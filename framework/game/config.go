@@ -35,17 +35,69 @@ type ServersConfig struct {
 	HandleTimeOut    int    `json:"handleTimeOut"`
 	RPCTimeOut       int    `json:"rpcTimeOut"`
 	MaxRunRoutineNum int    `json:"maxRunRoutineNum"`
+	Weight           int    `json:"weight"` // 加权负载均衡的权重，默认 1
 }
 
 type ConnectorConfig struct {
 	ID         string `json:"id"`
 	Host       string `json:"host"`
+	ClientHost string `json:"clientHost"` // 客户端连接使用的地址，注册到服务发现，其他 connector 排空时把客户端引导到这里
 	ClientPort int    `json:"clientPort"`
+	TcpPort    int    `json:"tcpPort"` // 原生 TCP 客户端端口，0 不开启
 	Frontend   bool   `json:"frontend"`
 	ServerType string `json:"serverType"`
+	Balancer   string `json:"balancer"` // 负载均衡策略：random roundRobin weighted hash
+	Sticky     bool   `json:"sticky"`   // 选中后绑定到 session，之后的请求都发往同一个服务器
+	// 客户端消息按 cid 分片处理的 worker 数量和每个 worker 的队列长度，不配置使用默认值
+	WorkerNum       int `json:"workerNum"`
+	WorkerQueueSize int `json:"workerQueueSize"`
+	// 握手返回给客户端的心跳间隔（秒）、协议版本和路由字典，连续 heartbeatMiss 次没收到心跳断开连接
+	HeartTime     uint8             `json:"heartTime"`
+	HeartbeatMiss int               `json:"heartbeatMiss"`
+	ProtoVersion  uint8             `json:"protoVersion"`
+	Version       string            `json:"version"`
+	Dict          map[string]uint16 `json:"dict"`
+	// 客户端握手时声明支持解压后，超过 compressThreshold 字节的 Data 用 zlib 压缩，0 不压缩
+	CompressThreshold int `json:"compressThreshold"`
+	// 证书和私钥的路径，都配置时 clientPort 以 wss 提供服务，文件更新后自动重新加载
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// 每个连接的发送队列长度、队列满时的策略（dropOldest dropPush disconnect，默认 disconnect）和写超时（秒）
+	WriteQueueSize int    `json:"writeQueueSize"`
+	WritePolicy    string `json:"writePolicy"`
+	WriteTimeout   int    `json:"writeTimeout"`
+	// 客户端发包限流，不配置不限流
+	RateLimit *RateLimitConfig `json:"rateLimit"`
+	// 连接建立后多少秒内没有登录（绑定 uid）就踢下线，0 不检测
+	AuthTimeout int `json:"authTimeout"`
+	// 排空时等待客户端自己重连的秒数，超时后踢下线
+	DrainTimeout int `json:"drainTimeout"`
+}
+
+// Scheme 客户端连接使用的协议，配置了证书时为 wss，gate 按它下发给客户端
+func (c *ConnectorConfig) Scheme() string {
+	if c.CertFile != "" && c.KeyFile != "" {
+		return "wss"
+	}
+	return "ws"
+}
+
+// RateLimit 令牌桶：每秒补充 rate 个令牌，最多突发 burst 个
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// RateLimitConfig 每个连接按 rate、burst 限流，routes 中的路由（完整路由，如 game.unionHandler.createRoom）再单独限流
+type RateLimitConfig struct {
+	RateLimit
+	Routes        map[string]RateLimit `json:"routes"`
+	MaxViolations int                  `json:"maxViolations"` // 一分钟内被限流多少次后踢下线，0 不踢
+	BanTime       int                  `json:"banTime"`       // 踢下线后封禁 ip 的秒数，0 不封禁
 }
 type NatsConfig struct {
-	Url string `json:"url"`
+	Url      string `json:"url"`
+	Envelope string `json:"envelope"` // 服务器之间消息的编码：binary（默认）、json，滚动升级时先配置为 json
 }
 
 type GameConfigValue map[string]any
@@ -148,6 +200,15 @@ func (c *Config) GetConnector(serverId string) *ConnectorConfig {
 	return nil
 }
 
+func (c *Config) GetServer(serverId string) *ServersConfig {
+	for _, config := range c.ServersConf.Servers {
+		if config.ID == serverId {
+			return config
+		}
+	}
+	return nil
+}
+
 func (c *Config) GetConnectorByServerType(serverType string) *ConnectorConfig {
 	for _, v := range c.ServersConf.Connector {
 		if v.ServerType == serverType {
@@ -0,0 +1,15 @@
+package msError
+
+import "errors"
+
+// 框架层的错误，connector 路由、转发失败时返回给客户端
+var (
+	RouteUnsupported  = NewError(501, errors.New("路由不存在"))
+	NoServerFound     = NewError(502, errors.New("没有可用的服务器"))
+	RemoteSendFail    = NewError(503, errors.New("消息转发失败"))
+	MessageDecodeFail = NewError(504, errors.New("消息解析失败"))
+	HandleFail        = NewError(505, errors.New("消息处理失败"))
+	RequestTimeout    = NewError(506, errors.New("请求超时"))
+	Unauthorized      = NewError(507, errors.New("未登录"))
+	RateLimited       = NewError(508, errors.New("请求过于频繁"))
+)
@@ -1,6 +1,7 @@
 package msError
 
 import (
+	"encoding/json"
 	"errors"
 
 	"google.golang.org/grpc/codes"
@@ -16,6 +17,34 @@ func (e *Error) Error() string {
 	return e.Err.Error()
 }
 
+// 返回客户端的格式与 common.Result 一致：{"code": 1, "msg": "..."}
+func (e *Error) MarshalJSON() ([]byte, error) {
+	msg := ""
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return json.Marshal(struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}{
+		Code: e.Code,
+		Msg:  msg,
+	})
+}
+
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	e.Code = v.Code
+	e.Err = errors.New(v.Msg)
+	return nil
+}
+
 func NewError(code int, err error) *Error {
 	return &Error{Code: code, Err: err}
 }
@@ -2,7 +2,11 @@ package net
 
 type Connection interface {
 	Close()
+	// SendMessage 响应、握手等消息，SendPush 推送消息；都不阻塞，发送队列满时按 WritePolicy 处理
 	SendMessage(buf []byte) error
+	SendPush(buf []byte) error
+	// SendAndClose 发送完 buf（以及之前排队的消息）后关闭连接，用于 Kick
+	SendAndClose(buf []byte) error
 	GetSession() *Session
 }
 
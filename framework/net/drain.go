@@ -0,0 +1,184 @@
+package net
+
+import (
+	"common/discovery"
+	"common/logs"
+	"context"
+	"encoding/json"
+	"framework/protocol"
+	stdnet "net"
+	"strconv"
+	"time"
+)
+
+// ReconnectPushRoute 排空时推送给客户端的路由，客户端收到后断开并连接 body 中的 connector，
+// body 没有地址时重新走 gate 的 /register 获取
+const ReconnectPushRoute = "ServerReconnectPush"
+
+// KickServerRestart 排空超时后还没断开的连接被踢下线的原因
+const KickServerRestart = "server restart"
+
+const (
+	drainCheckInterval = 100 * time.Millisecond
+	// 排空超时后等待正在处理的请求响应的最长时间
+	drainPendingWait = 10 * time.Second
+	// 踢下线后等待 Kick 包发出、连接断开的最长时间
+	drainKickWait = 5 * time.Second
+)
+
+// ConnectorService connector 注册到服务发现中的名字
+const ConnectorService = "connector"
+
+// 注册信息 Meta 中的字段
+const (
+	metaServerId = "serverId"
+	metaTcpPort  = "tcpPort"
+)
+
+// ServiceLister 查询服务发现中在线的服务，discovery.Lister 实现了它
+type ServiceLister interface {
+	List(name string) ([]discovery.Server, error)
+}
+
+// ConnectorServer connector 注册到服务发现的信息：客户端连接的地址、serverId 和 tcp 端口
+func ConnectorServer(serverId, clientHost string, clientPort, tcpPort int, ttl int64) discovery.Server {
+	meta := map[string]string{metaServerId: serverId}
+	if tcpPort > 0 {
+		meta[metaTcpPort] = strconv.Itoa(tcpPort)
+	}
+	return discovery.Server{
+		Name: ConnectorService,
+		Addr: stdnet.JoinHostPort(clientHost, strconv.Itoa(clientPort)),
+		Ttl:  ttl,
+		Meta: meta,
+	}
+}
+
+type reconnectBody struct {
+	Host    string `json:"host,omitempty"`
+	Port    int    `json:"port,omitempty"`
+	TcpPort int    `json:"tcpPort,omitempty"`
+}
+
+// Drain 排空 connector，用于滚动发布：
+//  1. 关闭监听，不再接受新连接
+//  2. 给每个客户端推送 ReconnectPushRoute，地址从服务发现中在线的其他 connector 轮流选择
+//  3. 等客户端自己断开，直到 ctx 结束
+//  4. 还在线的连接等它们正在处理的请求响应后踢下线，等 Kick 包发出、连接断开后返回，removeClt 会登记用户下线
+func (m *WsManager) Drain(ctx context.Context) {
+	if !m.draining.CompareAndSwap(false, true) {
+		return
+	}
+	logs.Info("connector %s draining", m.ServerId)
+	m.closeListeners()
+	targets := m.reconnectTargets()
+	for i, conn := range m.getClts() {
+		var body reconnectBody
+		if len(targets) > 0 {
+			body = targets[i%len(targets)]
+		}
+		m.pushReconnect(conn, body)
+	}
+	m.waitUntil(ctx, func() bool {
+		return len(m.getClts()) == 0
+	})
+	pendingCtx, cancel := context.WithTimeout(context.Background(), drainPendingWait)
+	defer cancel()
+	m.waitUntil(pendingCtx, m.pending.empty)
+	clts := m.getClts()
+	for _, conn := range clts {
+		m.kick(conn, KickServerRestart)
+	}
+	// 写 goroutine 发出 Kick 后关闭连接，直接 Close 会丢掉还在队列里的 Kick 包
+	kickCtx, kickCancel := context.WithTimeout(context.Background(), drainKickWait)
+	defer kickCancel()
+	m.waitUntil(kickCtx, func() bool {
+		return len(m.getClts()) == 0
+	})
+	logs.Info("connector %s drained, %d connections kicked", m.ServerId, len(clts))
+}
+
+// Draining 是否正在排空
+func (m *WsManager) Draining() bool {
+	return m.draining.Load()
+}
+
+func (m *WsManager) pushReconnect(conn Connection, body reconnectBody) {
+	data, _ := json.Marshal(body)
+	buf, err := m.encodeData(&protocol.Message{
+		Type:  protocol.Push,
+		Route: ReconnectPushRoute,
+		Data:  data,
+	}, conn.GetSession())
+	if err != nil {
+		logs.Error("reconnect push encode err: %v", err)
+		return
+	}
+	// 按响应发送，dropPush 策略下也不会被丢弃
+	if err := conn.SendMessage(buf); err != nil {
+		logs.Error("reconnect push send err: %v", err)
+	}
+}
+
+// 服务发现中在线的其他 connector，宕机的 connector 租约过期后不在其中；
+// 没有配置服务发现或者查询失败时返回空，客户端重新走 gate 获取地址
+func (m *WsManager) reconnectTargets() []reconnectBody {
+	if m.Discovery == nil {
+		return nil
+	}
+	servers, err := m.Discovery.List(ConnectorService)
+	if err != nil {
+		logs.Error("list connectors err: %v", err)
+		return nil
+	}
+	var targets []reconnectBody
+	for _, s := range servers {
+		if s.Meta[metaServerId] == m.ServerId {
+			continue
+		}
+		host, port, err := stdnet.SplitHostPort(s.Addr)
+		if err != nil {
+			logs.Error("invalid connector addr: %s, err: %v", s.Addr, err)
+			continue
+		}
+		body := reconnectBody{Host: host}
+		body.Port, _ = strconv.Atoi(port)
+		body.TcpPort, _ = strconv.Atoi(s.Meta[metaTcpPort])
+		targets = append(targets, body)
+	}
+	return targets
+}
+
+func (m *WsManager) closeListeners() {
+	m.Lock()
+	defer m.Unlock()
+	if m.wsServer != nil {
+		// 升级后的 websocket 连接已经被接管，不会被关闭
+		_ = m.wsServer.Close()
+	}
+	if m.tcpListener != nil {
+		_ = m.tcpListener.Close()
+	}
+}
+
+func (m *WsManager) getClts() []Connection {
+	m.RLock()
+	defer m.RUnlock()
+	clts := make([]Connection, 0, len(m.clts))
+	for _, clt := range m.clts {
+		clts = append(clts, clt)
+	}
+	return clts
+}
+
+func (m *WsManager) waitUntil(ctx context.Context, done func() bool) {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for !done() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package net
+
+import (
+	"sync"
+	"time"
+)
+
+// 默认心跳间隔（秒）和连续错过多少次心跳后断开连接
+const (
+	defaultHeartbeat     = 3
+	defaultHeartbeatMiss = 3
+)
+
+// 应用层心跳：握手后为每个连接启动定时器，收到客户端的包就重置，
+// 连续 HeartbeatMiss 个心跳间隔都没收到时认为客户端已经失联
+type heartbeatTimers struct {
+	sync.Mutex
+	timeout time.Duration
+	timers  map[string]*time.Timer // cid -> 超时定时器
+}
+
+func newHeartbeatTimers(interval time.Duration, miss int) *heartbeatTimers {
+	return &heartbeatTimers{
+		timeout: interval * time.Duration(miss),
+		timers:  make(map[string]*time.Timer),
+	}
+}
+
+// start 握手成功后开始检测，重复握手时重新计时
+func (h *heartbeatTimers) start(cid string, onTimeout func()) {
+	if h.timeout <= 0 {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	if t, ok := h.timers[cid]; ok {
+		t.Stop()
+	}
+	h.timers[cid] = time.AfterFunc(h.timeout, func() {
+		h.remove(cid)
+		onTimeout()
+	})
+}
+
+// reset 收到客户端的包，重新计时；还没握手的连接不处理
+func (h *heartbeatTimers) reset(cid string) {
+	h.Lock()
+	defer h.Unlock()
+	if t, ok := h.timers[cid]; ok {
+		t.Reset(h.timeout)
+	}
+}
+
+// 连接断开，停止检测
+func (h *heartbeatTimers) remove(cid string) {
+	h.Lock()
+	defer h.Unlock()
+	if t, ok := h.timers[cid]; ok {
+		t.Stop()
+		delete(h.timers, cid)
+	}
+}
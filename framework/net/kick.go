@@ -0,0 +1,87 @@
+package net
+
+import (
+	"common/logs"
+	"encoding/json"
+	"framework/protocol"
+	"framework/remote"
+)
+
+// 服务器踢下线的原因，客户端在 Kick 包的 reason 字段中收到
+const (
+	KickDuplicateLogin = "duplicate login"
+	KickRateLimit      = "rate limit"
+	KickAuthTimeout    = "auth timeout"
+)
+
+type kickBody struct {
+	Reason string `json:"reason"`
+}
+
+// kick 给客户端发送 Kick 包后关闭连接；先从 uid 索引中移除，推送不会再发到旧连接，
+// 连接关闭后 readMsg 退出，由 removeClt 完成清理
+func (m *WsManager) kick(clt Connection, reason string) {
+	session := clt.GetSession()
+	session.SetKicked()
+	m.Lock()
+	m.unindexUid(session.GetUid(), session.Cid)
+	m.Unlock()
+	data, _ := json.Marshal(kickBody{Reason: reason})
+	buf, err := protocol.Encode(protocol.Kick, data)
+	if err != nil {
+		logs.Error("encode kick packet err: %v", err)
+		clt.Close()
+		return
+	}
+	logs.Info("kick client[%s], uid=%s, reason=%s", session.Cid, session.GetUid(), reason)
+	if err := clt.SendAndClose(buf); err != nil {
+		logs.Error("send kick packet err: %v", err)
+	}
+}
+
+// kickUid 踢掉本 connector 上 uid 的连接，except 为保留的 cid（新登录的连接）
+func (m *WsManager) kickUid(uid, except, reason string) {
+	m.RLock()
+	clts := make([]Connection, 0, len(m.uidClts[uid]))
+	for cid, clt := range m.uidClts[uid] {
+		if cid != except {
+			clts = append(clts, clt)
+		}
+	}
+	m.RUnlock()
+	for _, clt := range clts {
+		m.kick(clt, reason)
+	}
+}
+
+// kickRemote 用户之前登录在其他 connector 上，通知它踢掉旧连接
+func (m *WsManager) kickRemote(connectorId, uid string) {
+	msg := &remote.Msg{
+		Src:  m.ServerId,
+		Dst:  connectorId,
+		Uid:  uid,
+		Type: remote.KickType,
+	}
+	data, err := remote.EncodeMsg(msg)
+	if err != nil {
+		logs.Error("encode kick msg err: %v", err)
+		return
+	}
+	if err := m.RemoteClt.SendMsg(connectorId, data); err != nil {
+		logs.Error("send kick to %s err: %v, uid=%s", connectorId, err, uid)
+	}
+}
+
+// 收到其他 connector 的踢人通知。用户又很快登录回本 connector 时 presence 已经指向这里，
+// 这条通知是过期的，不能踢掉最新的连接
+func (m *WsManager) remoteKick(msg *remote.Msg) {
+	if m.Presence != nil {
+		online, err := m.Presence.Lookup([]string{msg.Uid})
+		if err != nil {
+			logs.Error("presence lookup err: %v, uid=%s", err, msg.Uid)
+		} else if online[msg.Uid] == m.ServerId {
+			return
+		}
+	}
+	m.kickUid(msg.Uid, "", KickDuplicateLogin)
+}
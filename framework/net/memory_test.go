@@ -0,0 +1,331 @@
+package net
+
+import (
+	"common/config"
+	"common/discovery"
+	"common/logs"
+	"context"
+	"encoding/json"
+	"framework/game"
+	"framework/msError"
+	"framework/node"
+	"framework/protocol"
+	"framework/remote"
+	"framework/serializer"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 测试用连接，把发给客户端的包写入 sent
+type testConnection struct {
+	session *Session
+	sent    chan []byte
+	m       *WsManager // 不为空时 SendAndClose 后和真实连接一样从 manager 中移除
+}
+
+func (c *testConnection) Close() {}
+
+func (c *testConnection) SendMessage(buf []byte) error {
+	c.sent <- buf
+	return nil
+}
+
+func (c *testConnection) SendPush(buf []byte) error {
+	return c.SendMessage(buf)
+}
+
+func (c *testConnection) SendAndClose(buf []byte) error {
+	if err := c.SendMessage(buf); err != nil {
+		return err
+	}
+	if c.m != nil {
+		// 真实连接的写 goroutine 发出后关闭连接，读 goroutine 退出时调用 removeClt
+		go c.m.removeClt(c.session.Cid)
+	}
+	return nil
+}
+
+func (c *testConnection) GetSession() *Session {
+	return c.session
+}
+
+// 配置和日志只初始化一次，前一个测试的 goroutine 可能还在读
+var setupOnce sync.Once
+
+// connector -> MemoryBus -> node.App -> MemoryBus -> connector 整个链路在一个进程内完成
+func setupMemoryCluster(t *testing.T) (*WsManager, *testConnection) {
+	setupOnce.Do(setupConfig)
+	bus := remote.NewMemoryBus()
+
+	app := node.Default()
+	app.SetRemoteClient(bus.Factory())
+	handlers := make(node.LogicHandler)
+	handlers["testHandler.echo"] = func(session *remote.Session, msg []byte) any {
+		return map[string]any{"uid": session.GetUid(), "msg": string(msg)}
+	}
+	// game 通过同步调用向 hall 查询
+	handlers["testHandler.call"] = func(session *remote.Session, msg []byte) any {
+		var resp map[string]any
+		if err := session.Call(context.Background(), "hall", "userHandler.permission", string(msg), &resp); err != nil {
+			return err
+		}
+		return resp
+	}
+	// 调用同类型的节点，唯一的 game 节点是自己，不能选中
+	handlers["testHandler.self"] = func(session *remote.Session, msg []byte) any {
+		if err := session.Call(context.Background(), "game", "testHandler.echo", string(msg), nil); err != nil {
+			return err
+		}
+		return nil
+	}
+	app.RegisterHandler(handlers)
+	if err := app.Run("game-test"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(app.Close)
+
+	hall := node.Default()
+	hall.SetRemoteClient(bus.Factory())
+	hallHandlers := make(node.LogicHandler)
+	hallHandlers["userHandler.permission"] = func(session *remote.Session, msg []byte) any {
+		return map[string]any{"uid": session.GetUid(), "union": string(msg)}
+	}
+	hall.RegisterHandler(hallHandlers)
+	if err := hall.Run("hall-test"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(hall.Close)
+
+	m := NewWsManager()
+	m.ServerId = "connector-test"
+	m.ConnectorHandlers = make(LogicHandler)
+	// 没有配置 AnonymousRoutes，Start 默认放行 connector.entryHandler.entry
+	m.ConnectorHandlers["entryHandler.entry"] = func(session *Session, body []byte) (any, error) {
+		return map[string]any{"entry": "ok"}, nil
+	}
+	m.RemoteClt = bus.Factory()("connector-test", m.RemoteReadChan)
+	if err := m.RemoteClt.Run(); err != nil {
+		t.Fatal(err)
+	}
+	m.Start()
+
+	conn := &testConnection{session: NewSession("cid-test"), sent: make(chan []byte, 16), m: m}
+	conn.session.Uid = "10001"
+	m.addClt(conn)
+	return m, conn
+}
+
+func setupConfig() {
+	config.Conf = &config.Config{}
+	logs.InitLog("test")
+	game.Conf = &game.Config{
+		ServersConf: game.ServersConf{
+			Connector: []*game.ConnectorConfig{
+				{ID: "connector-test", ServerType: "connector"},
+			},
+			Servers: []*game.ServersConfig{
+				{ID: "game-test", ServerType: "game", HandleTimeOut: 1},
+				{ID: "hall-test", ServerType: "hall", HandleTimeOut: 1, RPCTimeOut: 1},
+			},
+			TypeServer: map[string][]*game.ServersConfig{
+				"game": {{ID: "game-test", ServerType: "game", HandleTimeOut: 1}},
+				"hall": {{ID: "hall-test", ServerType: "hall", HandleTimeOut: 1, RPCTimeOut: 1}},
+			},
+		},
+	}
+}
+
+// 测试连接，uid 为空时是未登录的连接
+func addTestConn(m *WsManager, cid, uid string) *testConnection {
+	conn := &testConnection{session: NewSession(cid), sent: make(chan []byte, 16), m: m}
+	m.addClt(conn)
+	if uid != "" {
+		conn.session.Bind(uid)
+	}
+	return conn
+}
+
+func sendRequest(t *testing.T, m *WsManager, conn *testConnection, id uint, route string, data []byte) {
+	body, err := protocol.MessageEncode(&protocol.Message{Type: protocol.Request, ID: id, Route: route, Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := protocol.Encode(protocol.Data, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.workerChan(conn.session.Cid) <- &MsgPack{Cid: conn.session.Cid, body: buf}
+}
+
+func receiveResponse(t *testing.T, conn *testConnection) *protocol.Message {
+	select {
+	case buf := <-conn.sent:
+		packet, err := protocol.Decode(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return packet.MessageBody()
+	case <-time.After(3 * time.Second):
+		t.Fatal("no response")
+	}
+	return nil
+}
+
+// receiveKick 等待 Kick 包，返回踢下线的原因
+func receiveKick(t *testing.T, conn *testConnection) string {
+	select {
+	case buf := <-conn.sent:
+		packet, err := protocol.Decode(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if packet.Type != protocol.Kick {
+			t.Fatalf("expected kick packet, got: %v", packet.Type)
+		}
+		var body kickBody
+		if err := json.Unmarshal(buf[protocol.HeaderLen:], &body); err != nil {
+			t.Fatal(err)
+		}
+		return body.Reason
+	case <-time.After(3 * time.Second):
+		t.Fatal("connection not kicked")
+	}
+	return ""
+}
+
+// 请求经过 connector -> MemoryBus -> node 再返回，所有用例共用一个集群
+func TestMemoryRemoteRequest(t *testing.T) {
+	m, conn := setupMemoryCluster(t)
+	anonymous := addTestConn(m, "cid-anonymous", "")
+	tests := []struct {
+		name     string
+		conn     *testConnection
+		route    string
+		data     string
+		wantCode int            // 错误响应的 code，0 表示成功
+		wantBody map[string]any // 成功响应需要包含的字段
+	}{
+		{"echo", conn, "game.testHandler.echo", "hello", 0, map[string]any{"uid": "10001", "msg": "hello"}},
+		// game 通过同步调用向 hall 查询
+		{"call", conn, "game.testHandler.call", "union-1", 0, map[string]any{"uid": "10001", "union": `"union-1"`}},
+		{"callSelf", conn, "game.testHandler.self", "", msError.NoServerFound.Code, nil},
+		{"unknownServer", conn, "user.userHandler.register", "", msError.NoServerFound.Code, nil},
+		// node 上没有这个 handler，不会响应，connector 在 HandleTimeOut 后回超时错误
+		{"timeout", conn, "game.testHandler.missing", "", msError.RequestTimeout.Code, nil},
+		{"unauthenticated", anonymous, "game.testHandler.echo", "hello", msError.Unauthorized.Code, nil},
+		{"anonymousRoute", anonymous, "connector.entryHandler.entry", "", 0, map[string]any{"entry": "ok"}},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uint(i + 1)
+			sendRequest(t, m, tt.conn, id, tt.route, []byte(tt.data))
+			message := receiveResponse(t, tt.conn)
+			if message.Type != protocol.Response || message.ID != id || message.Error != (tt.wantCode != 0) {
+				t.Fatalf("unexpected response: %+v", message)
+			}
+			var resp map[string]any
+			if err := json.Unmarshal(message.Data, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode != 0 && resp["code"] != float64(tt.wantCode) {
+				t.Fatalf("unexpected error: %v, want code %d", resp, tt.wantCode)
+			}
+			for k, v := range tt.wantBody {
+				if resp[k] != v {
+					t.Fatalf("unexpected body: %v, want %v", resp, tt.wantBody)
+				}
+			}
+		})
+	}
+}
+
+func TestDuplicateLoginKick(t *testing.T) {
+	m, conn := setupMemoryCluster(t)
+	conn.session.Bind("10001")
+	other := addTestConn(m, "cid-other", "10001")
+	if reason := receiveKick(t, conn); reason != KickDuplicateLogin {
+		t.Fatalf("unexpected kick reason: %v", reason)
+	}
+	if clts := m.getCltsByUids([]string{"10001"}); len(clts) != 1 || clts[0] != other {
+		t.Fatalf("unexpected connections for uid: %v", clts)
+	}
+	select {
+	case <-other.sent:
+		t.Fatal("new connection should not be kicked")
+	default:
+	}
+}
+
+// 推送按连接的序列化方式选择 game 节点已经编码好的 payload，没有对应 payload 时从 json 转换
+func TestPushPayloads(t *testing.T) {
+	m, conn := setupMemoryCluster(t)
+	conn.session.Bind("20001")
+	pb := addTestConn(m, "cid-protobuf", "20002")
+	codec, _ := serializer.Get(serializer.Protobuf)
+	pb.session.SetSerializer(codec)
+	m.Response(&remote.Msg{
+		PushUser: []string{"20001", "20002"},
+		Body:     &protocol.Message{Type: protocol.Push, Route: protocol.ServerMessagePush, Data: []byte(`{"type":1}`)},
+		Payloads: map[string][]byte{serializer.Protobuf: {0x08, 0x01}},
+	})
+	if message := receiveResponse(t, conn); string(message.Data) != `{"type":1}` {
+		t.Fatalf("unexpected json push: %s", message.Data)
+	}
+	if message := receiveResponse(t, pb); string(message.Data) != "\x08\x01" {
+		t.Fatalf("unexpected protobuf push: %x", message.Data)
+	}
+}
+
+func TestAuthTimeout(t *testing.T) {
+	m, _ := setupMemoryCluster(t)
+	m.AuthTimeout = 50 * time.Millisecond
+	conn := addTestConn(m, "cid-idle", "")
+	if reason := receiveKick(t, conn); reason != KickAuthTimeout {
+		t.Fatalf("unexpected kick reason: %v", reason)
+	}
+}
+
+// 测试用服务发现，返回固定的服务列表
+type testLister []discovery.Server
+
+func (l testLister) List(name string) ([]discovery.Server, error) {
+	var servers []discovery.Server
+	for _, s := range l {
+		if s.Name == name {
+			servers = append(servers, s)
+		}
+	}
+	return servers, nil
+}
+
+func TestDrain(t *testing.T) {
+	m, conn := setupMemoryCluster(t)
+	// 自己也在列表中（还没来得及注销），不能被选为重连目标
+	m.Discovery = testLister{
+		ConnectorServer("connector-test", "10.0.0.1", 12000, 0, 10),
+		ConnectorServer("connector-other", "10.0.0.2", 12000, 12001, 10),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	// 测试连接不会自己断开，等到超时后被踢下线
+	m.Drain(ctx)
+	if !m.Draining() {
+		t.Fatal("manager should be draining")
+	}
+	message := receiveResponse(t, conn)
+	var body reconnectBody
+	if err := json.Unmarshal(message.Data, &body); err != nil {
+		t.Fatal(err)
+	}
+	if message.Type != protocol.Push || message.Route != ReconnectPushRoute || body.Host != "10.0.0.2" || body.Port != 12000 || body.TcpPort != 12001 {
+		t.Fatalf("unexpected reconnect push: %+v %+v", message, body)
+	}
+	if reason := receiveKick(t, conn); reason != KickServerRestart {
+		t.Fatalf("unexpected kick reason: %v", reason)
+	}
+	// Drain 等被踢的连接断开后才返回
+	if clts := m.getClts(); len(clts) != 0 {
+		t.Fatalf("kicked connections should be removed, got %d", len(clts))
+	}
+}
@@ -0,0 +1,79 @@
+package net
+
+import (
+	"common/logs"
+	"common/metrics"
+	"framework/msError"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware 包装 connector 本地 handler，route 为注册时的路由，形如：entryHandler.entry
+type Middleware func(route string, next HandlerFunc) HandlerFunc
+
+// Handle 注册路由，middlewares 只作用于这个路由，按传入顺序由外到内执行
+func (l LogicHandler) Handle(route string, handler HandlerFunc, middlewares ...Middleware) {
+	l[route] = Chain(route, handler, middlewares...)
+}
+
+func Chain(route string, handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](route, handler)
+	}
+	return handler
+}
+
+// Recover handler panic 时记录堆栈并返回 HandleFail，由 WsManager 回错误响应
+func Recover() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *Session, body []byte) (resp any, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logs.Error("handler panic, route=%s, cid=%s, err=%v\n%s", route, session.Cid, r, debug.Stack())
+					resp, err = nil, msError.HandleFail
+				}
+			}()
+			return next(session, body)
+		}
+	}
+}
+
+// Auth 要求连接已经登录（session 中有 uid）
+func Auth() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *Session, body []byte) (any, error) {
+			if len(session.Uid) <= 0 {
+				return nil, msError.Unauthorized
+			}
+			return next(session, body)
+		}
+	}
+}
+
+// Logger 记录请求和耗时
+func Logger() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *Session, body []byte) (any, error) {
+			start := time.Now()
+			resp, err := next(session, body)
+			logs.Info("handle route=%s, cid=%s, uid=%s, cost=%v, err=%v", route, session.Cid, session.Uid, time.Since(start), err)
+			return resp, err
+		}
+	}
+}
+
+// Metrics 统计调用次数、失败次数和耗时，panic 也算失败
+func Metrics() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *Session, body []byte) (any, error) {
+			start := time.Now()
+			failed := true
+			defer func() {
+				metrics.ObserveHandler(route, time.Since(start), failed)
+			}()
+			resp, err := next(session, body)
+			failed = err != nil
+			return resp, err
+		}
+	}
+}
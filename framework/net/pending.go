@@ -0,0 +1,81 @@
+package net
+
+import (
+	"sync"
+	"time"
+)
+
+// 转发到后端、还没收到响应的 request，超时后由 connector 回超时错误，迟到的响应直接丢弃
+type pendingRequests struct {
+	sync.Mutex
+	reqs map[string]map[uint]*time.Timer // cid -> message id -> 超时定时器，timeout 为 0 时定时器为 nil
+}
+
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{
+		reqs: make(map[string]map[uint]*time.Timer),
+	}
+}
+
+func (p *pendingRequests) add(cid string, id uint, timeout time.Duration, onTimeout func()) {
+	p.Lock()
+	defer p.Unlock()
+	ids, ok := p.reqs[cid]
+	if !ok {
+		ids = make(map[uint]*time.Timer)
+		p.reqs[cid] = ids
+	}
+	if t, ok := ids[id]; ok && t != nil {
+		t.Stop()
+	}
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() {
+			if p.done(cid, id) {
+				onTimeout()
+			}
+		})
+	}
+	ids[id] = timer
+}
+
+// done 请求结束（收到响应或超时），返回 false 说明请求已经不在等待中
+func (p *pendingRequests) done(cid string, id uint) bool {
+	p.Lock()
+	defer p.Unlock()
+	ids, ok := p.reqs[cid]
+	if !ok {
+		return false
+	}
+	timer, ok := ids[id]
+	if !ok {
+		return false
+	}
+	if timer != nil {
+		timer.Stop()
+	}
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(p.reqs, cid)
+	}
+	return true
+}
+
+// 连接断开，清理它所有等待中的请求
+func (p *pendingRequests) removeConn(cid string) {
+	p.Lock()
+	defer p.Unlock()
+	for _, timer := range p.reqs[cid] {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	delete(p.reqs, cid)
+}
+
+// empty 没有等待响应的请求，排空时使用
+func (p *pendingRequests) empty() bool {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.reqs) == 0
+}
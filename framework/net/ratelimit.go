@@ -0,0 +1,208 @@
+package net
+
+import (
+	"framework/game"
+	stdnet "net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 统计违规次数的窗口，窗口内超过 MaxViolations 次踢下线
+const violationWindow = time.Minute
+
+// tokenBucket 每秒补充 rate 个令牌，最多攒 burst 个，每个包消耗一个
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(l game.RateLimit, now time.Time) *tokenBucket {
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   l.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refund 退还一个令牌
+func (b *tokenBucket) refund() {
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// connLimit 一个连接的令牌桶和违规计数
+type connLimit struct {
+	conn        *tokenBucket
+	routes      map[string]*tokenBucket
+	violations  int
+	windowStart time.Time
+}
+
+// rateLimiter 按连接限流，路由单独配置的再按路由限流；conf 为空时不限流
+type rateLimiter struct {
+	sync.Mutex
+	conf  *game.RateLimitConfig
+	conns map[string]*connLimit // cid -> 限流状态
+}
+
+func newRateLimiter(conf *game.RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		conf:  conf,
+		conns: make(map[string]*connLimit),
+	}
+}
+
+// allowConn 连接读到一帧数据时按连接限流，在投递到 worker 之前调用，超限的帧不会进入共享的 worker 队列。
+// 返回是否放行，以及窗口内的违规次数是否已经达到踢下线的阈值
+func (r *rateLimiter) allowConn(cid string) (ok bool, kick bool) {
+	if r.disabled() {
+		return true, false
+	}
+	now := time.Now()
+	r.Lock()
+	defer r.Unlock()
+	c := r.connLimit(cid, now)
+	if c.conn.allow(now) {
+		return true, false
+	}
+	return false, r.violate(c, now)
+}
+
+// allowRoute worker 解析出路由后按路由限流，没有单独配置的路由直接放行。
+// 路由超限时退还 allowConn 扣掉的连接令牌，只有两个桶都通过的包才占用连接的额度
+func (r *rateLimiter) allowRoute(cid, route string) (ok bool, kick bool) {
+	if r.disabled() {
+		return true, false
+	}
+	l, limited := r.conf.Routes[route]
+	if !limited || l.Rate <= 0 {
+		return true, false
+	}
+	now := time.Now()
+	r.Lock()
+	defer r.Unlock()
+	c := r.connLimit(cid, now)
+	b, exist := c.routes[route]
+	if !exist {
+		b = newTokenBucket(l, now)
+		c.routes[route] = b
+	}
+	if b.allow(now) {
+		return true, false
+	}
+	c.conn.refund()
+	return false, r.violate(c, now)
+}
+
+func (r *rateLimiter) disabled() bool {
+	return r.conf == nil || r.conf.Rate <= 0
+}
+
+// 调用方需要持有锁
+func (r *rateLimiter) connLimit(cid string, now time.Time) *connLimit {
+	c, exist := r.conns[cid]
+	if !exist {
+		c = &connLimit{
+			conn:   newTokenBucket(r.conf.RateLimit, now),
+			routes: make(map[string]*tokenBucket),
+		}
+		r.conns[cid] = c
+	}
+	return c
+}
+
+// 记录一次违规，返回窗口内的违规次数是否已经达到踢下线的阈值；调用方需要持有锁
+func (r *rateLimiter) violate(c *connLimit, now time.Time) bool {
+	if now.Sub(c.windowStart) > violationWindow {
+		c.windowStart = now
+		c.violations = 0
+	}
+	c.violations++
+	return r.conf.MaxViolations > 0 && c.violations >= r.conf.MaxViolations
+}
+
+// 连接断开，清理限流状态
+func (r *rateLimiter) remove(cid string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.conns, cid)
+}
+
+// ipBlacklist 被踢的 ip 在一段时间内不能再连接
+type ipBlacklist struct {
+	sync.Mutex
+	until map[string]time.Time
+}
+
+func newIpBlacklist() *ipBlacklist {
+	return &ipBlacklist{
+		until: make(map[string]time.Time),
+	}
+}
+
+func (b *ipBlacklist) ban(ip string, d time.Duration) {
+	if ip == "" || d <= 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.until[ip] = time.Now().Add(d)
+}
+
+func (b *ipBlacklist) banned(ip string) bool {
+	b.Lock()
+	defer b.Unlock()
+	until, ok := b.until[ip]
+	if !ok {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(b.until, ip)
+		return false
+	}
+	return true
+}
+
+// 连接的对端 ip，解析不了时返回空，不参与封禁
+func remoteIp(addr stdnet.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := stdnet.SplitHostPort(addr.String())
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// websocket 升级前的对端 ip
+func remoteIpOf(r *http.Request) string {
+	host, _, err := stdnet.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return ""
+	}
+	return host
+}
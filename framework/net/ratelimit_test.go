@@ -0,0 +1,129 @@
+package net
+
+import (
+	"framework/game"
+	"framework/protocol"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(game.RateLimit{Rate: 2, Burst: 2}, now)
+	if !b.allow(now) || !b.allow(now) {
+		t.Fatal("burst should be allowed")
+	}
+	if b.allow(now) {
+		t.Fatal("bucket should be empty")
+	}
+	// 每秒 2 个，半秒补充一个
+	if !b.allow(now.Add(500 * time.Millisecond)) {
+		t.Fatal("token should be refilled")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	r := newRateLimiter(&game.RateLimitConfig{
+		RateLimit: game.RateLimit{Rate: 100, Burst: 100},
+		Routes: map[string]game.RateLimit{
+			"game.unionHandler.createRoom": {Rate: 0.1, Burst: 1},
+		},
+		MaxViolations: 2,
+	})
+	// 读到一帧先按连接限流，worker 解析出路由后再按路由限流
+	allow := func(cid, route string) (bool, bool) {
+		if ok, kick := r.allowConn(cid); !ok {
+			return ok, kick
+		}
+		return r.allowRoute(cid, route)
+	}
+	if ok, _ := allow("cid", "game.unionHandler.createRoom"); !ok {
+		t.Fatal("first createRoom should be allowed")
+	}
+	// 路由单独限流，不影响其他路由
+	if ok, kick := allow("cid", "game.unionHandler.createRoom"); ok || kick {
+		t.Fatalf("second createRoom should be limited without kick: %v %v", ok, kick)
+	}
+	if ok, _ := allow("cid", "game.gameHandler.gameMessageNotify"); !ok {
+		t.Fatal("other routes should be allowed")
+	}
+	if ok, _ := allow("other", "game.unionHandler.createRoom"); !ok {
+		t.Fatal("limits are per connection")
+	}
+	if ok, kick := allow("cid", "game.unionHandler.createRoom"); ok || !kick {
+		t.Fatalf("repeat offender should be kicked: %v %v", ok, kick)
+	}
+	r.remove("cid")
+	if ok, _ := allow("cid", "game.unionHandler.createRoom"); !ok {
+		t.Fatal("state should be reset after remove")
+	}
+}
+
+func TestRateLimiterRouteRefund(t *testing.T) {
+	r := newRateLimiter(&game.RateLimitConfig{
+		RateLimit: game.RateLimit{Rate: 0.001, Burst: 2},
+		Routes: map[string]game.RateLimit{
+			"game.unionHandler.createRoom": {Rate: 0.001, Burst: 1},
+		},
+	})
+	for i := 0; i < 2; i++ {
+		if ok, _ := r.allowConn("cid"); !ok {
+			t.Fatalf("packet %d should pass the connection bucket", i)
+		}
+		ok, _ := r.allowRoute("cid", "game.unionHandler.createRoom")
+		if ok != (i == 0) {
+			t.Fatalf("createRoom %d: unexpected result %v", i, ok)
+		}
+	}
+	// 被路由拒绝的包退还了连接令牌，连接还有一个包的额度
+	if ok, _ := r.allowConn("cid"); !ok {
+		t.Fatal("route-limited packet should not consume the connection bucket")
+	}
+	if ok, _ := r.allowConn("cid"); ok {
+		t.Fatal("connection bucket should be empty")
+	}
+}
+
+func TestIpBlacklist(t *testing.T) {
+	b := newIpBlacklist()
+	b.ban("10.0.0.1", time.Minute)
+	b.ban("10.0.0.2", -time.Minute)
+	if !b.banned("10.0.0.1") || b.banned("10.0.0.2") || b.banned("10.0.0.3") {
+		t.Fatal("unexpected blacklist state")
+	}
+}
+
+// 踢下线后连接关闭前还会读到帧，这些帧直接丢弃，不再重复踢下线和封禁
+func TestRateLimitKickOnce(t *testing.T) {
+	setupOnce.Do(setupConfig)
+	m := NewWsManager()
+	m.RateLimit = &game.RateLimitConfig{
+		RateLimit:     game.RateLimit{Rate: 0.1, Burst: 1},
+		MaxViolations: 1,
+		BanTime:       60,
+	}
+	m.limiter = newRateLimiter(m.RateLimit)
+	m.blacklist = newIpBlacklist()
+	conn := &testConnection{session: NewSession("cid-kick"), sent: make(chan []byte, 16)}
+	conn.session.Ip = "10.0.0.1"
+	m.addClt(conn)
+	frame, _ := protocol.Encode(protocol.Heartbeat, nil)
+	if !m.allowFrame(conn, frame) {
+		t.Fatal("first frame should be allowed")
+	}
+	for i := 0; i < 5; i++ {
+		if m.allowFrame(conn, frame) {
+			t.Fatal("over-limit frame should be dropped")
+		}
+	}
+	if len(conn.sent) != 1 {
+		t.Fatalf("expected one kick packet, got %d", len(conn.sent))
+	}
+	if !m.blacklist.banned("10.0.0.1") {
+		t.Fatal("ip should be banned")
+	}
+	packet := &protocol.Packet{Type: protocol.Data, Body: protocol.Message{Type: protocol.Notify, Route: "game.gameHandler.gameMessageNotify"}}
+	if m.allowRoute(packet, conn) {
+		t.Fatal("kicked connection should not reach handlers")
+	}
+}
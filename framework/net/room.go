@@ -0,0 +1,38 @@
+package net
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RoomIdFunc 从 session 或请求体中取出房间号，返回空字符串表示该请求与房间无关
+type RoomIdFunc func(session *Session, body []byte) string
+
+// RoomRoutes 房间相关的路由，key 形如：game.unionHandler.joinRoom
+type RoomRoutes map[string]RoomIdFunc
+
+// SessionRoomId 房间号保存在 session 中（game 节点进入房间后通过 session.Put 同步过来）
+func SessionRoomId(key string) RoomIdFunc {
+	return func(session *Session, body []byte) string {
+		v, ok := session.Get(key)
+		if !ok || v == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// BodyRoomId 房间号在请求体的 json 字段中，比如 joinRoom 的 roomID
+func BodyRoomId(field string) RoomIdFunc {
+	return func(session *Session, body []byte) string {
+		var req map[string]any
+		if err := json.Unmarshal(body, &req); err != nil {
+			return ""
+		}
+		v, ok := req[field]
+		if !ok || v == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -1,21 +1,58 @@
 package net
 
-import "sync"
+import (
+	"framework/serializer"
+	"sync"
+)
 
 type Session struct {
 	sync.RWMutex
-	Cid  string
-	Uid  string
-	data map[string]any
+	Cid      string
+	Uid      string
+	Ip       string // 客户端 ip，限流封禁使用
+	data     map[string]any
+	servers  map[string]string        // serverType -> 绑定的 serverId，粘性路由使用
+	onBind   func(oldUid, uid string) // 绑定 uid 后由 WsManager 处理：建立 uid 索引、登记用户在线
+	compress bool                     // 握手时协商启用压缩，超过阈值的 Data 用 zlib 压缩后发送
+	codec    serializer.Serializer    // 握手时客户端选择的消息体序列化方式，默认 json
+	kicked   bool                     // 已经被踢下线，连接关闭前读到的帧直接丢弃
 }
 
 func NewSession(cid string) *Session {
 	return &Session{
-		Cid:  cid,
-		data: make(map[string]any),
+		Cid:     cid,
+		data:    make(map[string]any),
+		servers: make(map[string]string),
+		codec:   serializer.Default(),
 	}
 }
 
+// Bind 登录成功后绑定 uid
+func (s *Session) Bind(uid string) {
+	s.Lock()
+	oldUid := s.Uid
+	s.Uid = uid
+	onBind := s.onBind
+	s.Unlock()
+	if onBind != nil {
+		onBind(oldUid, uid)
+	}
+}
+
+// HashKey 一致性哈希负载均衡的 key：uid，未登录时用 cid
+func (s *Session) HashKey() string {
+	if uid := s.GetUid(); uid != "" {
+		return uid
+	}
+	return s.Cid
+}
+
+func (s *Session) GetUid() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.Uid
+}
+
 func (s *Session) Put(k string, v any) {
 	s.Lock()
 	defer s.Unlock()
@@ -38,3 +75,66 @@ func (s *Session) SetData(uid string, data map[string]any) {
 		}
 	}
 }
+
+// Snapshot 复制 uid 和 session 数据，转发到其他节点时编码的是副本，不和 Put、SetData 并发读写同一个 map
+func (s *Session) Snapshot() (string, map[string]any) {
+	s.RLock()
+	defer s.RUnlock()
+	data := make(map[string]any, len(s.data))
+	for k, v := range s.data {
+		data[k] = v
+	}
+	return s.Uid, data
+}
+
+func (s *Session) GetServer(serverType string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	serverId, ok := s.servers[serverType]
+	return serverId, ok
+}
+
+func (s *Session) BindServer(serverType, serverId string) {
+	s.Lock()
+	defer s.Unlock()
+	s.servers[serverType] = serverId
+}
+
+func (s *Session) SetCompress(compress bool) {
+	s.Lock()
+	defer s.Unlock()
+	s.compress = compress
+}
+
+func (s *Session) Compress() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.compress
+}
+
+func (s *Session) SetSerializer(codec serializer.Serializer) {
+	s.Lock()
+	defer s.Unlock()
+	s.codec = codec
+}
+
+func (s *Session) Serializer() serializer.Serializer {
+	s.RLock()
+	defer s.RUnlock()
+	return s.codec
+}
+
+// SetKicked 标记连接已被踢下线，返回是否是第一次标记
+func (s *Session) SetKicked() bool {
+	s.Lock()
+	defer s.Unlock()
+	first := !s.kicked
+	s.kicked = true
+	return first
+}
+
+func (s *Session) Kicked() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.kicked
+}
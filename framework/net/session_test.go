@@ -0,0 +1,31 @@
+package net
+
+import (
+	"sync"
+	"testing"
+)
+
+// 转发消息时编码的是 Snapshot 的副本，和 Put 并发时不能有数据竞争（go test -race）
+func TestSessionSnapshot(t *testing.T) {
+	s := NewSession("cid-1")
+	s.Bind("uid-1")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s.Put("roomId", i)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		uid, data := s.Snapshot()
+		if uid != "uid-1" {
+			t.Fatalf("uid = %s", uid)
+		}
+		data["local"] = true
+	}
+	wg.Wait()
+	if _, ok := s.Get("local"); ok {
+		t.Fatal("snapshot shares the session map")
+	}
+}
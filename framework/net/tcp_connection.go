@@ -0,0 +1,126 @@
+package net
+
+import (
+	"bufio"
+	"common/logs"
+	"common/metrics"
+	"errors"
+	"framework/protocol"
+	stdnet "net"
+	"time"
+)
+
+// TcpConnection 原生 TCP 客户端连接，和 websocket 使用同样的 pomelo packet 格式（4 字节包头），
+// 读到的 packet 投递到同一个 worker pool，后续处理和 WsConnection 完全一样
+type TcpConnection struct {
+	Cid       string
+	Conn      stdnet.Conn
+	wsManager *WsManager
+	ReadChan  chan *MsgPack
+	queue     *writeQueue
+	Session   *Session
+}
+
+func (c *TcpConnection) Run() {
+	go c.readMsg()
+	go c.writeMsg()
+}
+
+func (c *TcpConnection) readMsg() {
+	defer func() {
+		c.wsManager.removeClt(c.Cid)
+	}()
+	reader := bufio.NewReader(c.Conn)
+	for {
+		// TCP 没有 ping/pong，期间没读到任何 packet（包括心跳）就断开连接
+		if err := c.Conn.SetReadDeadline(time.Now().Add(c.wsManager.readTimeout())); err != nil {
+			logs.Error("SetReadDeadline err: %v", err)
+			return
+		}
+		buf, err := protocol.ReadPacket(reader, c.wsManager.maxPacketSize())
+		if err != nil {
+			logs.Info("client[%v] tcp read err: %v", c.Cid, err)
+			return
+		}
+		// 先按连接限流，超限的包不占用共享的 worker 队列
+		if !c.wsManager.allowFrame(c, buf) {
+			continue
+		}
+		c.ReadChan <- &MsgPack{
+			Cid:  c.Cid,
+			body: buf,
+		}
+	}
+}
+
+func (c *TcpConnection) writeMsg() {
+	for {
+		select {
+		case <-c.queue.done:
+			return
+		case <-c.queue.notify:
+			for _, o := range c.queue.take() {
+				if o.buf != nil {
+					_ = c.Conn.SetWriteDeadline(time.Now().Add(c.wsManager.writeTimeout()))
+					if _, err := c.Conn.Write(o.buf); err != nil {
+						logs.Error("client[%v] write message err: %v", c.Cid, err)
+						metrics.ObserveWriteFail()
+						c.Close()
+						return
+					}
+				}
+				if o.close {
+					// SendAndClose：前面的消息都已发出，关闭连接，readMsg 随之退出
+					c.Close()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *TcpConnection) Close() {
+	c.queue.close()
+	if c.Conn != nil {
+		_ = c.Conn.Close()
+	}
+}
+
+func (c *TcpConnection) SendMessage(buf []byte) error {
+	return c.send(outbound{buf: buf})
+}
+
+func (c *TcpConnection) SendPush(buf []byte) error {
+	return c.send(outbound{buf: buf, push: true})
+}
+
+func (c *TcpConnection) SendAndClose(buf []byte) error {
+	return c.send(outbound{buf: buf, close: true})
+}
+
+func (c *TcpConnection) send(o outbound) error {
+	err := c.queue.put(o)
+	if errors.Is(err, ErrWriteQueueFull) {
+		logs.Warn("client[%v] write queue full, disconnect", c.Cid)
+		c.Close()
+	}
+	return err
+}
+
+func (c *TcpConnection) GetSession() *Session {
+	return c.Session
+}
+
+func NewTcpConnection(conn stdnet.Conn, wsManager *WsManager) *TcpConnection {
+	cid := newCid(wsManager.ServerId)
+	session := NewSession(cid)
+	session.Ip = remoteIp(conn.RemoteAddr())
+	return &TcpConnection{
+		Conn:      conn,
+		wsManager: wsManager,
+		Cid:       cid,
+		queue:     wsManager.newWriteQueue(),
+		ReadChan:  wsManager.workerChan(cid),
+		Session:   session,
+	}
+}
@@ -0,0 +1,62 @@
+package net
+
+import (
+	"encoding/json"
+	"framework/protocol"
+	stdnet "net"
+	"testing"
+	"time"
+)
+
+func TestTcpConnectionRequest(t *testing.T) {
+	m, _ := setupMemoryCluster(t)
+	// TCP 连接没有登录，允许匿名访问测试路由
+	m.AnonymousRoutes = []string{"game.testHandler.echo"}
+	l, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		_ = m.ServeTCP(l)
+	}()
+
+	conn, err := stdnet.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(3 * time.Second))
+
+	// 握手和请求放在同一次写入里，服务端按包头长度拆分
+	handshake, _ := protocol.Encode(protocol.Handshake, []byte(`{"sys":{}}`))
+	body, _ := protocol.MessageEncode(&protocol.Message{Type: protocol.Request, ID: 3, Route: "game.testHandler.echo", Data: []byte(`"tcp"`)})
+	request, _ := protocol.Encode(protocol.Data, body)
+	if _, err := conn.Write(append(handshake, request...)); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := protocol.ReadPacket(conn, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet, err := protocol.Decode(buf); err != nil || packet.Type != protocol.Handshake {
+		t.Fatalf("expected handshake response, got %v %v", packet, err)
+	}
+	buf, err = protocol.ReadPacket(conn, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet, err := protocol.Decode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := packet.MessageBody()
+	var resp map[string]any
+	if err := json.Unmarshal(message.Data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if message.ID != 3 || resp["msg"] != `"tcp"` {
+		t.Fatalf("unexpected response: %+v %v", message, resp)
+	}
+}
@@ -0,0 +1,91 @@
+package net
+
+import (
+	"common/logs"
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// 证书文件的检查间隔，证书续期后不需要重启 connector
+const certCheckInterval = 30 * time.Second
+
+// certReloader 握手时提供当前证书，后台定时检查证书和私钥文件的修改时间，变化后重新加载；
+// 加载失败（比如只替换了一个文件）时继续使用旧证书，下次检查再试
+type certReloader struct {
+	sync.RWMutex
+	certFile string
+	keyFile  string
+	cert     *tls.Certificate
+	certMod  time.Time
+	keyMod   time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate 用于 tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.cert, nil
+}
+
+// reload 文件修改时间变化时重新加载，返回是否加载了新证书
+func (r *certReloader) reload() (bool, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return false, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	r.RLock()
+	changed := r.cert == nil || !certInfo.ModTime().Equal(r.certMod) || !keyInfo.ModTime().Equal(r.keyMod)
+	r.RUnlock()
+	if !changed {
+		return false, nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	r.Lock()
+	r.cert = &cert
+	r.certMod = certInfo.ModTime()
+	r.keyMod = keyInfo.ModTime()
+	r.Unlock()
+	return true, nil
+}
+
+func (r *certReloader) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		ok, err := r.reload()
+		if err != nil {
+			logs.Error("reload tls certificate %s err:%v", r.certFile, err)
+			continue
+		}
+		if ok {
+			logs.Info("tls certificate %s reloaded", r.certFile)
+		}
+	}
+}
+
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+}
@@ -0,0 +1,93 @@
+package net
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// 生成自签名证书写入 certFile、keyFile，并把文件修改时间设为 mod
+func writeTestCert(t *testing.T, certFile, keyFile, cn string, mod time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func certCN(t *testing.T, r *certReloader) string {
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	mod := time.Now().Add(-time.Minute)
+	writeTestCert(t, certFile, keyFile, "old", mod)
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cn := certCN(t, r); cn != "old" {
+		t.Fatalf("unexpected certificate: %s", cn)
+	}
+	// 文件没有变化时不重新加载
+	if ok, err := r.reload(); ok || err != nil {
+		t.Fatalf("unexpected reload: %v %v", ok, err)
+	}
+	// 只替换了证书、私钥还是旧的，加载失败，继续使用旧证书
+	writeTestCert(t, certFile, filepath.Join(dir, "other.pem"), "new", mod.Add(time.Second))
+	if _, err := r.reload(); err == nil {
+		t.Fatal("mismatched key pair should fail")
+	}
+	if cn := certCN(t, r); cn != "old" {
+		t.Fatalf("old certificate should be kept, got: %s", cn)
+	}
+	writeTestCert(t, certFile, keyFile, "new", mod.Add(2*time.Second))
+	if ok, err := r.reload(); !ok || err != nil {
+		t.Fatalf("certificate not reloaded: %v %v", ok, err)
+	}
+	if cn := certCN(t, r); cn != "new" {
+		t.Fatalf("unexpected certificate: %s", cn)
+	}
+}
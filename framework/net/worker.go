@@ -0,0 +1,47 @@
+package net
+
+import "hash/fnv"
+
+// 默认 worker 数量和每个 worker 的队列长度，可在 ConnectorConfig 中配置
+const (
+	defaultWorkerNum       = 16
+	defaultWorkerQueueSize = 1024
+)
+
+// 按 cid 分片的 worker pool：同一连接的消息总是进入同一个 worker，保证顺序；不同连接分散到不同 worker 并行处理
+type workerPool struct {
+	queues []chan *MsgPack
+}
+
+func newWorkerPool(num, queueSize int) *workerPool {
+	if num <= 0 {
+		num = defaultWorkerNum
+	}
+	if queueSize <= 0 {
+		queueSize = defaultWorkerQueueSize
+	}
+	p := &workerPool{
+		queues: make([]chan *MsgPack, num),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan *MsgPack, queueSize)
+	}
+	return p
+}
+
+// 连接对应的 worker 队列
+func (p *workerPool) queue(cid string) chan *MsgPack {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cid))
+	return p.queues[h.Sum32()%uint32(len(p.queues))]
+}
+
+func (p *workerPool) run(handle func(body *MsgPack)) {
+	for _, q := range p.queues {
+		go func(q chan *MsgPack) {
+			for body := range q {
+				handle(body)
+			}
+		}(q)
+	}
+}
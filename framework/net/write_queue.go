@@ -0,0 +1,143 @@
+package net
+
+import (
+	"common/metrics"
+	"errors"
+	"sync"
+)
+
+// 写队列满时的处理策略，对应 ConnectorConfig.WritePolicy
+const (
+	DropOldest = "dropOldest" // 丢弃队列中最早的推送，保留响应；队列里全是响应时断开
+	DropPush   = "dropPush"   // 丢弃推送，保留响应；队列里全是响应时断开
+	Disconnect = "disconnect" // 直接断开，客户端重连后重新拉取状态
+)
+
+const defaultWriteQueueSize = 1024
+
+var (
+	ErrWriteQueueFull = errors.New("write queue full")
+	ErrConnClosed     = errors.New("connection closed")
+)
+
+type outbound struct {
+	buf   []byte
+	push  bool
+	close bool // SendAndClose 的关闭标记，前面的消息发出后关闭连接
+}
+
+// writeQueue 连接的发送队列，入队不阻塞：一个客户端卡住只影响它自己，不会拖住 Response 和 push 的 goroutine。
+// 写 goroutine 收到 notify 后一次取走队列中所有消息，done 关闭后退出
+type writeQueue struct {
+	sync.Mutex
+	policy  string
+	size    int
+	items   []outbound
+	closing bool // 已经放入关闭标记，之后的消息不再入队
+	notify  chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newWriteQueue(size int, policy string) *writeQueue {
+	if size <= 0 {
+		size = defaultWriteQueueSize
+	}
+	switch policy {
+	case DropOldest, DropPush:
+	default:
+		policy = Disconnect
+	}
+	return &writeQueue{
+		policy: policy,
+		size:   size,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// put 返回 ErrWriteQueueFull 时调用方需要断开连接；按策略丢弃的消息不返回错误
+func (q *writeQueue) put(o outbound) error {
+	q.Lock()
+	if q.closing || q.isClosed() {
+		q.Unlock()
+		return ErrConnClosed
+	}
+	if !o.close && len(q.items) >= q.size {
+		keep, err := q.makeRoom(o)
+		if err != nil || !keep {
+			q.Unlock()
+			return err
+		}
+	}
+	q.items = append(q.items, o)
+	q.closing = o.close
+	q.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// makeRoom 队列满时按策略腾出位置，返回新消息是否入队
+func (q *writeQueue) makeRoom(o outbound) (bool, error) {
+	// 响应丢了客户端的请求就等不到结果，两种丢弃策略都只丢推送
+	switch q.policy {
+	case DropOldest:
+		if q.dropFirstPush() {
+			return true, nil
+		}
+		if o.push {
+			// 队列里全是响应，新的推送就是最早的推送
+			metrics.ObserveWriteDrop()
+			return false, nil
+		}
+	case DropPush:
+		if o.push {
+			metrics.ObserveWriteDrop()
+			return false, nil
+		}
+		if q.dropFirstPush() {
+			return true, nil
+		}
+	}
+	metrics.ObserveSlowDisconnect()
+	return false, ErrWriteQueueFull
+}
+
+// 丢弃队列中最早的推送，没有推送时返回 false
+func (q *writeQueue) dropFirstPush() bool {
+	for i, item := range q.items {
+		if item.push {
+			metrics.ObserveWriteDrop()
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// take 取走队列中所有消息
+func (q *writeQueue) take() []outbound {
+	q.Lock()
+	defer q.Unlock()
+	items := q.items
+	q.items = nil
+	return items
+}
+
+func (q *writeQueue) close() {
+	q.once.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *writeQueue) isClosed() bool {
+	select {
+	case <-q.done:
+		return true
+	default:
+		return false
+	}
+}
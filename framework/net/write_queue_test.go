@@ -0,0 +1,88 @@
+package net
+
+import (
+	"errors"
+	"testing"
+)
+
+func queued(q *writeQueue) []string {
+	var out []string
+	for _, o := range q.take() {
+		out = append(out, string(o.buf))
+	}
+	return out
+}
+
+func TestWriteQueueDropOldest(t *testing.T) {
+	q := newWriteQueue(2, DropOldest)
+	for _, s := range []string{"a", "b", "c"} {
+		if err := q.put(outbound{buf: []byte(s), push: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := queued(q); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Fatalf("unexpected queue: %v", got)
+	}
+}
+
+func TestWriteQueueDropOldestKeepsResponse(t *testing.T) {
+	q := newWriteQueue(2, DropOldest)
+	_ = q.put(outbound{buf: []byte("resp1")})
+	_ = q.put(outbound{buf: []byte("push1"), push: true})
+	// 最早的是响应，跳过它丢弃后面的推送
+	if err := q.put(outbound{buf: []byte("push2"), push: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.put(outbound{buf: []byte("resp2")}); err != nil {
+		t.Fatal(err)
+	}
+	// 队列里全是响应时新的推送被丢弃
+	if err := q.put(outbound{buf: []byte("push3"), push: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := queued(q); len(got) != 2 || got[0] != "resp1" || got[1] != "resp2" {
+		t.Fatalf("unexpected queue: %v", got)
+	}
+	_ = q.put(outbound{buf: []byte("resp3")})
+	_ = q.put(outbound{buf: []byte("resp4")})
+	// 新的响应放不下时断开
+	if err := q.put(outbound{buf: []byte("resp5")}); !errors.Is(err, ErrWriteQueueFull) {
+		t.Fatalf("expected queue full, got: %v", err)
+	}
+}
+
+func TestWriteQueueDropPush(t *testing.T) {
+	q := newWriteQueue(2, DropPush)
+	_ = q.put(outbound{buf: []byte("push1"), push: true})
+	_ = q.put(outbound{buf: []byte("resp1")})
+	// 队列满时新的推送直接丢弃
+	if err := q.put(outbound{buf: []byte("push2"), push: true}); err != nil {
+		t.Fatal(err)
+	}
+	// 响应挤掉队列里的推送
+	if err := q.put(outbound{buf: []byte("resp2")}); err != nil {
+		t.Fatal(err)
+	}
+	// 队列里全是响应时断开
+	if err := q.put(outbound{buf: []byte("resp3")}); !errors.Is(err, ErrWriteQueueFull) {
+		t.Fatalf("expected queue full, got: %v", err)
+	}
+	if got := queued(q); len(got) != 2 || got[0] != "resp1" || got[1] != "resp2" {
+		t.Fatalf("unexpected queue: %v", got)
+	}
+}
+
+func TestWriteQueueDisconnect(t *testing.T) {
+	q := newWriteQueue(1, "")
+	_ = q.put(outbound{buf: []byte("a"), push: true})
+	if err := q.put(outbound{buf: []byte("b"), push: true}); !errors.Is(err, ErrWriteQueueFull) {
+		t.Fatalf("expected queue full, got: %v", err)
+	}
+	// 关闭标记不受队列长度限制，之后的消息不再入队
+	if err := q.put(outbound{buf: []byte("kick"), close: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.put(outbound{buf: []byte("c")}); !errors.Is(err, ErrConnClosed) {
+		t.Fatalf("expected closed, got: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package net
 
 import (
 	"common/logs"
+	"common/metrics"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -19,13 +21,12 @@ var (
 )
 
 type WsConnection struct {
-	Cid        string
-	Conn       *websocket.Conn
-	wsManager  *WsManager
-	ReadChan   chan *MsgPack
-	WriteChan  chan []byte
-	Session    *Session
-	pingTicker *time.Ticker
+	Cid       string
+	Conn      *websocket.Conn
+	wsManager *WsManager
+	ReadChan  chan *MsgPack
+	queue     *writeQueue
+	Session   *Session
 }
 
 func (c *WsConnection) Run() {
@@ -54,7 +55,8 @@ func (c *WsConnection) readMsg() {
 		}
 		// 客户端发来的是 二进制消息
 		if messageType == websocket.BinaryMessage {
-			if c.ReadChan != nil {
+			// 先按连接限流，超限的帧不占用共享的 worker 队列
+			if c.ReadChan != nil && c.wsManager.allowFrame(c, msg) {
 				c.ReadChan <- &MsgPack{
 					Cid:  c.Cid,
 					body: msg,
@@ -67,20 +69,36 @@ func (c *WsConnection) readMsg() {
 }
 
 func (c *WsConnection) writeMsg() {
-	c.pingTicker = time.NewTicker(pingWait)
+	pingTicker := time.NewTicker(pingWait)
+	defer pingTicker.Stop()
 	for {
 		select {
-		case message, ok := <-c.WriteChan:
-			if !ok {
-				if err := c.Conn.WriteMessage(websocket.CloseMessage, nil); err != nil {
-					logs.Error("connection closed, err: %v", err)
+		case <-c.queue.done:
+			return
+		case <-c.queue.notify:
+			for _, o := range c.queue.take() {
+				if o.buf != nil {
+					// 数据帧也要设置写超时：客户端不读时 TCP 发送缓冲写满，WriteMessage 会一直阻塞
+					_ = c.Conn.SetWriteDeadline(time.Now().Add(c.wsManager.writeTimeout()))
+					if err := c.Conn.WriteMessage(websocket.BinaryMessage, o.buf); err != nil {
+						logs.Error("client[%v] write message err: %v", c.Cid, err)
+						metrics.ObserveWriteFail()
+						c.Close()
+						return
+					}
+				}
+				if o.close {
+					// SendAndClose：前面的消息都已发出，发送 close 帧后关闭连接，readMsg 随之退出
+					_ = c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+					closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+					if err := c.Conn.WriteMessage(websocket.CloseMessage, closeMsg); err != nil {
+						logs.Error("client[%v] write close err: %v", c.Cid, err)
+					}
+					c.Close()
+					return
 				}
-				return
-			}
-			if err := c.Conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
-				logs.Error("client[%v] write message err: %v", c.Cid, err)
 			}
-		case <-c.pingTicker.C:
+		case <-pingTicker.C:
 			// SetWriteDeadline：从 now + writeWait 这个截止时间之前，这个 WriteMessage(Ping) 必须完成；
 			// 如果底层网络卡住（对端不收、TCP 缓冲满、网络异常），导致写一直阻塞，超过 deadline 后写就会返回超时错误。
 			if err := c.Conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
@@ -91,6 +109,7 @@ func (c *WsConnection) writeMsg() {
 				logs.Error("client[%v] ping err: %v", c.Cid, err)
 				// ping 不通，就认为客户端下线
 				c.Close()
+				return
 			}
 			logs.Info("ping...")
 		}
@@ -98,17 +117,32 @@ func (c *WsConnection) writeMsg() {
 }
 
 func (c *WsConnection) Close() {
+	c.queue.close()
 	if c.Conn != nil {
 		_ = c.Conn.Close()
 	}
-	if c.pingTicker != nil {
-		c.pingTicker.Stop()
-	}
 }
 
 func (c *WsConnection) SendMessage(buf []byte) error {
-	c.WriteChan <- buf
-	return nil
+	return c.send(outbound{buf: buf})
+}
+
+func (c *WsConnection) SendPush(buf []byte) error {
+	return c.send(outbound{buf: buf, push: true})
+}
+
+func (c *WsConnection) SendAndClose(buf []byte) error {
+	return c.send(outbound{buf: buf, close: true})
+}
+
+// 入队不阻塞，队列满且策略要求断开时关闭连接
+func (c *WsConnection) send(o outbound) error {
+	err := c.queue.put(o)
+	if errors.Is(err, ErrWriteQueueFull) {
+		logs.Warn("client[%v] write queue full, disconnect", c.Cid)
+		c.Close()
+	}
+	return err
 }
 
 func (c *WsConnection) GetSession() *Session {
@@ -126,14 +160,21 @@ func (c *WsConnection) PongHandler(data string) error {
 	return nil
 }
 
+// websocket 和 TCP 连接共用的 cid 格式
+func newCid(serverId string) string {
+	return fmt.Sprintf("%s-%s-%d", uuid.New().String(), serverId, atomic.AddUint64(&cidBase, 1))
+}
+
 func NewWsConnection(conn *websocket.Conn, wsManager *WsManager) *WsConnection {
-	cid := fmt.Sprintf("%s-%s-%d", uuid.New().String(), wsManager.ServerId, atomic.AddUint64(&cidBase, 1))
+	cid := newCid(wsManager.ServerId)
+	session := NewSession(cid)
+	session.Ip = remoteIp(conn.RemoteAddr())
 	return &WsConnection{
 		Conn:      conn,
 		wsManager: wsManager,
 		Cid:       cid,
-		WriteChan: make(chan []byte, 1024),
-		ReadChan:  wsManager.cltReadChan,
-		Session:   NewSession(cid),
+		queue:     wsManager.newWriteQueue(),
+		ReadChan:  wsManager.workerChan(cid),
+		Session:   session,
 	}
 }
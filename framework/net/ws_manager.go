@@ -1,30 +1,37 @@
-// websocket 消息消费模式：多生产者 -> 单消费者，所有连接把消息发送到一个读通道
+// websocket 消息消费模式：多生产者 -> 按 cid 分片的 worker pool，每个连接的消息固定投递到其中一个 worker
 // 优点：
 //
 //	1.集中处理协议
 //	2.方便做路由/广播/鉴权/限流，按 cid 找连接、广播给所有人、按房间/用户路由
 //	3.降低连接对象的复杂度，WsConnection 只负责网络读写，业务处理不塞进连接里
+//	4.不同连接的消息由不同 worker 并行处理，同一连接的消息仍然按顺序处理
 //
 // 缺点：
 //
-//	1.单个 Manager 消费速度如果跟不上，CltReadChan 会被塞满（目前设置的 1024 buffer），然后连接的 readMsg() 往里写会阻塞，最后影响读网络包，可能导致超时/断线。
+//	1.某个 worker 消费速度如果跟不上，它的队列会被塞满（WorkerQueueSize），然后分到这个 worker 的连接 readMsg() 往里写会阻塞，
+//	  最后影响读网络包，可能导致超时/断线，但不会影响其他 worker 上的连接。
 //
-// 解决办法：如果业务处理很重，通常会在 Manager 里再开 worker pool（或者把 decode/handle 拆出去）避免单点瓶颈。
+// worker 数量和队列长度在 ConnectorConfig 的 workerNum、workerQueueSize 中配置
 package net
 
 import (
 	"common/logs"
-	"common/utils"
+	"common/metrics"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"framework/balancer"
+	"framework/directory"
 	"framework/game"
+	"framework/msError"
 	"framework/protocol"
 	"framework/remote"
-	"math/rand"
+	"framework/serializer"
+	stdnet "net"
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -50,34 +57,219 @@ type WsManager struct {
 	ServerId           string
 	CheckOriginHandler CheckOriginHandler
 	clts               map[string]Connection
-	cltReadChan        chan *MsgPack                         // 客户端 WS 入站消息通道：各连接读取到的消息统一投递到这里，由后续逻辑消费
+	workers            *workerPool                           // 客户端 WS 入站消息按 cid 分片投递到 worker，由 worker 消费
+	WorkerNum          int                                   // worker 数量
+	WorkerQueueSize    int                                   // 每个 worker 的队列长度
 	handlers           map[protocol.PackageType]EventHandler // 客户端 Packet 处理器
 	ConnectorHandlers  LogicHandler                          // 本地 connector 处理器
 	RemoteReadChan     chan []byte                           // 远端入站消息通道：来自 NATS server/集群的消息写入此处，由本地逻辑读取处理
 	RemoteClt          remote.Client
-	RemotePushChan     chan *remote.Msg // 专门处理 push 到客户端的 Channel
+	RemotePushChan     chan *remote.Msg                 // 专门处理 push 到客户端的 Channel
+	RoomDirectory      directory.RoomDirectory          // 房间所在的 game 节点，为空时按 serverType 随机选
+	RoomRoutes         RoomRoutes                       // 需要路由到房间所在节点的路由
+	Balancer           balancer.Balancer                // 选择后端服务器的负载均衡策略
+	pending            *pendingRequests                 // 已转发到后端、等待响应的 request
+	Presence           directory.Presence               // 登记用户连接在哪个 connector，后端按它推送
+	uidClts            map[string]map[string]Connection // uid -> cid -> 连接，推送时按 uid 查找连接
+	Sys                protocol.Sys                     // 握手时返回给客户端：心跳间隔、协议版本、路由字典
+	HeartbeatMiss      int                              // 连续错过多少次心跳后断开连接
+	CompressThreshold  int                              // 协商启用压缩的连接，Data 超过多少字节时压缩，0 不压缩
+	Routes             []string                         // 后端 handler 路由和推送路由，和本地 handler、房间路由一起生成路由字典
+	heartbeats         *heartbeatTimers                 // 每个连接的心跳超时定时器
+	CertFile           string                           // 证书和私钥都配置时 websocket 以 wss 提供服务
+	KeyFile            string
+	WriteQueueSize     int                   // 每个连接发送队列的长度
+	WritePolicy        string                // 发送队列满时的处理策略：dropOldest dropPush disconnect
+	WriteTimeout       time.Duration         // 写一条消息的超时，超时断开连接
+	RateLimit          *game.RateLimitConfig // 客户端发包限流，为空不限流
+	limiter            *rateLimiter
+	blacklist          *ipBlacklist  // 多次超限被踢的 ip，封禁期间拒绝连接
+	AnonymousRoutes    []string      // 不需要登录就能访问的完整路由，其他路由绑定 uid 之前一律拒绝；为空时 Start 默认放行本地的 entryHandler.entry
+	AuthTimeout        time.Duration // 连接建立后多久没有绑定 uid 就踢下线，0 不检测
+	Discovery          ServiceLister // 查询在线的其他 connector，排空时让客户端重连到它们
+	wsServer           *http.Server
+	tcpListener        stdnet.Listener
+	draining           atomic.Bool // 排空中，不再接受新连接
 }
 
+// 没有配置 AnonymousRoutes 时默认放行的本地登录 handler
+const defaultEntryRoute = "entryHandler.entry"
+
 type EventHandler func(packet *protocol.Packet, conn Connection) error
 
 type HandlerFunc func(session *Session, body []byte) (any, error)
 type LogicHandler map[string]HandlerFunc
 
 func (m *WsManager) Run(addr string) {
+	m.Start()
+	m.ServeWS(addr)
+}
+
+// ServeWS 监听 websocket，需要先调用 Start；配置了 CertFile、KeyFile 时使用 TLS，证书文件更新后自动重新加载
+func (m *WsManager) ServeWS(addr string) {
+	http.HandleFunc("/", m.serveWS)
+	server := &http.Server{Addr: addr}
+	m.Lock()
+	m.wsServer = server
+	m.Unlock()
+	if m.Draining() {
+		return
+	}
+	if m.CertFile == "" || m.KeyFile == "" {
+		err := server.ListenAndServe()
+		if errors.Is(err, http.ErrServerClosed) {
+			// Drain 关闭了监听
+			return
+		}
+		logs.Fatal("connector listen serve err:%v", err)
+		return
+	}
+	certs, err := newCertReloader(m.CertFile, m.KeyFile)
+	if err != nil {
+		logs.Fatal("connector load tls certificate err:%v", err)
+		return
+	}
+	go certs.watch(certCheckInterval)
+	server.TLSConfig = certs.tlsConfig()
+	logs.Info("connector wss listen on %s", addr)
+	// 证书由 TLSConfig.GetCertificate 提供，这里不传文件
+	err = server.ListenAndServeTLS("", "")
+	if errors.Is(err, http.ErrServerClosed) {
+		return
+	}
+	logs.Fatal("connector listen serve tls err:%v", err)
+}
+
+// ServeTCP 监听原生 TCP 客户端，需要先调用 Start；TCP 和 websocket 的连接由同一个 WsManager 管理，可以在同一个房间。
+// Drain 关闭监听后返回 nil
+func (m *WsManager) ServeTCP(listener stdnet.Listener) error {
+	m.Lock()
+	m.tcpListener = listener
+	m.Unlock()
+	if m.Draining() {
+		return listener.Close()
+	}
+	logs.Info("connector tcp listen on %s", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			var ne stdnet.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			if m.Draining() {
+				return nil
+			}
+			return err
+		}
+		if m.blacklist.banned(remoteIp(conn.RemoteAddr())) {
+			_ = conn.Close()
+			continue
+		}
+		clt := NewTcpConnection(conn, m)
+		m.addClt(clt)
+		clt.Run()
+	}
+}
+
+// TCP 连接的读超时：Start 之后不论是否握手都按心跳超时（heartbeat * miss），
+// 握手前连接没有心跳定时器，这个读超时就是它的空闲上限；只有 Start 之前（没有心跳配置）才按 pongWait
+func (m *WsManager) readTimeout() time.Duration {
+	if m.heartbeats != nil && m.heartbeats.timeout > 0 {
+		return m.heartbeats.timeout
+	}
+	return pongWait
+}
+
+// 写数据帧的超时，不配置时和 ping 的写超时一致
+func (m *WsManager) writeTimeout() time.Duration {
+	if m.WriteTimeout > 0 {
+		return m.WriteTimeout
+	}
+	return writeWait
+}
+
+func (m *WsManager) newWriteQueue() *writeQueue {
+	return newWriteQueue(m.WriteQueueSize, m.WritePolicy)
+}
+
+// 单个 packet 的最大长度，和 websocket 的读限制一致
+func (m *WsManager) maxPacketSize() int {
+	return int(maxMessageSize)
+}
+
+// Start 启动消息处理，不监听端口，Run 会调用它
+func (m *WsManager) Start() {
+	if m.Sys.Heartbeat == 0 {
+		m.Sys.Heartbeat = defaultHeartbeat
+	}
+	if m.HeartbeatMiss <= 0 {
+		m.HeartbeatMiss = defaultHeartbeatMiss
+	}
+	m.heartbeats = newHeartbeatTimers(time.Duration(m.Sys.Heartbeat)*time.Second, m.HeartbeatMiss)
+	m.limiter = newRateLimiter(m.RateLimit)
+	m.blacklist = newIpBlacklist()
+	m.defaultAnonymousRoutes()
+	// 服务端生成路由字典：配置中固定 code 的路由优先，再加入所有注册的路由，客户端握手时拿到同一份字典
+	protocol.SetDictionary(m.Sys.Dict)
+	protocol.AddRoutes(m.dictRoutes()...)
+	m.Sys.Dict = protocol.GetDictionary()
 	// 处理 WS 消息
-	go m.clientReadChanHandler()
+	m.workers = newWorkerPool(m.WorkerNum, m.WorkerQueueSize)
+	m.workers.run(m.decodeClientPack)
 	// 处理 NATS server 消息
 	go m.remoteReadChanHandler()
 	// 专门一个处理 push 的消息，提高吞吐量
 	go m.remotePushChanHandler()
-	http.HandleFunc("/", m.serveWS)
 	// 设置不同的消息处理器
 	m.setupEventHandler()
-	err := http.ListenAndServe(addr, nil)
-	logs.Fatal("connector listen serve err:%v", err)
+	if m.Presence != nil {
+		go m.refreshPresence()
+	}
+}
+
+// connector 的 serverType，本地 handler 的完整路由以它为前缀
+func (m *WsManager) serverType() string {
+	if conf := game.Conf.GetConnector(m.ServerId); conf != nil && conf.ServerType != "" {
+		return conf.ServerType
+	}
+	return "connector"
+}
+
+// 没有配置 AnonymousRoutes 时所有连接都无法登录，默认放行本地的登录路由
+func (m *WsManager) defaultAnonymousRoutes() {
+	if len(m.AnonymousRoutes) > 0 {
+		return
+	}
+	route := m.serverType() + "." + defaultEntryRoute
+	if _, ok := m.ConnectorHandlers[defaultEntryRoute]; !ok {
+		logs.Warn("connector %s has no anonymous routes and no %s handler, clients can not log in", m.ServerId, route)
+		return
+	}
+	logs.Warn("connector %s has no anonymous routes, allow %s by default", m.ServerId, route)
+	m.AnonymousRoutes = []string{route}
+}
+
+// 路由字典包含的路由：本地 handler（带上 connector 的 serverType）、房间路由和注册的后端路由
+func (m *WsManager) dictRoutes() []string {
+	serverType := m.serverType()
+	routes := make([]string, 0, len(m.ConnectorHandlers)+len(m.RoomRoutes)+len(m.Routes))
+	for route := range m.ConnectorHandlers {
+		routes = append(routes, serverType+"."+route)
+	}
+	for route := range m.RoomRoutes {
+		routes = append(routes, route)
+	}
+	routes = append(routes, ReconnectPushRoute)
+	return append(routes, m.Routes...)
 }
 
 func (m *WsManager) serveWS(writer http.ResponseWriter, request *http.Request) {
+	if m.blacklist.banned(remoteIpOf(request)) {
+		http.Error(writer, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
 	// websocket 基于 http
 	if m.websocketUpgrade == nil {
 		m.websocketUpgrade = &websocketUpgrade
@@ -94,57 +286,228 @@ func (m *WsManager) serveWS(writer http.ResponseWriter, request *http.Request) {
 	clt.Run()
 }
 
-func (m *WsManager) addClt(clt *WsConnection) {
+func (m *WsManager) addClt(clt Connection) {
+	session := clt.GetSession()
+	session.Lock()
+	session.onBind = func(oldUid, uid string) {
+		m.bindUid(clt, oldUid, uid)
+	}
+	session.Unlock()
 	m.Lock()
-	defer m.Unlock()
-	m.clts[clt.Cid] = clt
+	m.clts[session.Cid] = clt
+	m.Unlock()
+	m.checkAuth(clt)
+}
+
+// checkAuth AuthTimeout 后连接还没有绑定 uid 就踢下线，避免未登录的连接一直占用资源
+func (m *WsManager) checkAuth(clt Connection) {
+	if m.AuthTimeout <= 0 {
+		return
+	}
+	session := clt.GetSession()
+	time.AfterFunc(m.AuthTimeout, func() {
+		if _, ok := m.getClt(session.Cid); !ok || session.GetUid() != "" {
+			return
+		}
+		logs.Warn("client[%s] not authenticated in %v, ip=%s", session.Cid, m.AuthTimeout, session.Ip)
+		m.kick(clt, KickAuthTimeout)
+	})
+}
+
+// authorized 绑定 uid 之前只能访问 AnonymousRoutes 中的路由
+func (m *WsManager) authorized(session *Session, route string) bool {
+	if session.GetUid() != "" {
+		return true
+	}
+	for _, r := range m.AnonymousRoutes {
+		if r == route {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *WsManager) removeClt(cid string) {
-	m.clts[cid].Close()
 	m.Lock()
+	clt, ok := m.clts[cid]
 	delete(m.clts, cid)
+	uid := ""
+	online := false
+	if ok {
+		uid = clt.GetSession().GetUid()
+		m.unindexUid(uid, cid)
+		// 被重复登录踢掉的连接，用户在本 connector 上还有新连接
+		_, online = m.uidClts[uid]
+	}
 	m.Unlock()
+	if ok {
+		clt.Close()
+		if uid != "" && !online {
+			m.userOffline(uid)
+		}
+	}
+	m.pending.removeConn(cid)
+	m.heartbeats.remove(cid)
+	m.limiter.remove(cid)
 }
 
-func (m *WsManager) clientReadChanHandler() {
-	for {
-		select {
-		case body, ok := <-m.cltReadChan:
-			if ok {
-				// body 是 pomelo 协议，需要解析一下
-				m.decodeClientPack(body)
-			}
+// 连接绑定 uid 后加入 uid 索引，重复绑定时先从旧 uid 下移除。
+// 同一个 uid 只保留最新的连接，本 connector 和其他 connector 上的旧连接都会被踢下线
+func (m *WsManager) bindUid(clt Connection, oldUid, uid string) {
+	cid := clt.GetSession().Cid
+	m.Lock()
+	if _, ok := m.clts[cid]; !ok {
+		// 连接已经断开
+		m.Unlock()
+		return
+	}
+	m.unindexUid(oldUid, cid)
+	conns, ok := m.uidClts[uid]
+	if !ok {
+		conns = make(map[string]Connection)
+		m.uidClts[uid] = conns
+	}
+	conns[cid] = clt
+	m.Unlock()
+	m.kickUid(uid, cid, KickDuplicateLogin)
+	if prev := m.userOnline(uid); prev != "" && prev != m.ServerId {
+		m.kickRemote(prev, uid)
+	}
+}
+
+// 调用方需要持有写锁
+func (m *WsManager) unindexUid(uid, cid string) {
+	if uid == "" {
+		return
+	}
+	conns, ok := m.uidClts[uid]
+	if !ok {
+		return
+	}
+	delete(conns, cid)
+	if len(conns) == 0 {
+		delete(m.uidClts, uid)
+	}
+}
+
+func (m *WsManager) getCltsByUids(uids []string) []Connection {
+	m.RLock()
+	defer m.RUnlock()
+	res := make([]Connection, 0, len(uids))
+	for _, uid := range uids {
+		for _, conn := range m.uidClts[uid] {
+			res = append(res, conn)
 		}
 	}
+	return res
 }
 
-// 解析 pomelo 协议
-func (m *WsManager) decodeClientPack(body *MsgPack) {
-	logs.Info("receive msg:%v", string(body.body))
-	// 解析 pomelo Packet 包
-	packet, err := protocol.Decode(body.body)
+// 返回用户之前所在的 connector
+func (m *WsManager) userOnline(uid string) string {
+	if m.Presence == nil {
+		return ""
+	}
+	prev, err := m.Presence.Online(uid, m.ServerId)
 	if err != nil {
-		logs.Error("decode Packet err: %v", err)
-		return
+		logs.Error("presence online err: %v, uid=%s", err, uid)
+	}
+	return prev
+}
+
+// refreshPresence 定时续期本 connector 上在线用户的记录，connector 宕机后不再续期，记录在 PresenceTTL 后失效
+func (m *WsManager) refreshPresence() {
+	ticker := time.NewTicker(directory.PresenceTTL / 3)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := m.Presence.Refresh(m.onlineUids(), m.ServerId); err != nil {
+			logs.Error("presence refresh err: %v", err)
+		}
+	}
+}
+
+func (m *WsManager) onlineUids() []string {
+	m.RLock()
+	defer m.RUnlock()
+	uids := make([]string, 0, len(m.uidClts))
+	for uid := range m.uidClts {
+		uids = append(uids, uid)
 	}
-	if err := m.routeEvent(packet, body.Cid); err != nil {
-		logs.Error("routeEvent err: %v", err)
+	return uids
+}
+
+func (m *WsManager) userOffline(uid string) {
+	if m.Presence == nil {
 		return
 	}
+	if err := m.Presence.Offline(uid, m.ServerId); err != nil {
+		logs.Error("presence offline err: %v, uid=%s", err, uid)
+	}
 }
 
+// 连接读到的消息投递到的 worker 队列
+func (m *WsManager) workerChan(cid string) chan *MsgPack {
+	return m.workers.queue(cid)
+}
+
+// 解析 pomelo 协议，body 是 pomelo 协议
+func (m *WsManager) decodeClientPack(body *MsgPack) {
+	logs.Info("receive msg:%v", string(body.body))
+	// 解析 pomelo Packet 包，客户端可能在一帧中发送多个 packet
+	decoder := protocol.NewDecoder(0)
+	decoder.Feed(body.body)
+	for {
+		packet, err := decoder.Next()
+		if err != nil {
+			logs.Error("decode Packet err: %v", err)
+			if packet == nil {
+				// 包头错误，这一帧剩下的数据无法解析
+				return
+			}
+			// 能解析出 request ID 的，回一个错误响应
+			if packet.Type == protocol.Data {
+				message := packet.MessageBody()
+				if conn, ok := m.getClt(body.Cid); ok && message.Type == protocol.Request {
+					m.ErrorResponse(conn, message.ID, msError.MessageDecodeFail)
+				}
+			}
+			continue
+		}
+		if packet == nil {
+			break
+		}
+		// 客户端发来任何包都说明连接还活着
+		m.heartbeats.reset(body.Cid)
+		if err := m.routeEvent(packet, body.Cid); err != nil {
+			logs.Error("routeEvent err: %v", err)
+		}
+	}
+	if decoder.Buffered() > 0 {
+		logs.Error("decode Packet err: %v, cid=%s", protocol.ErrPacketTruncated, body.Cid)
+	}
+}
+
+// Close 关闭所有连接，和连接正常断开一样走 removeClt，登记用户下线并清理连接状态
 func (m *WsManager) Close() {
-	m.Lock()
-	defer m.Unlock()
-	for cid, conn := range m.clts {
-		conn.Close()
-		delete(m.clts, cid)
+	m.RLock()
+	cids := make([]string, 0, len(m.clts))
+	for cid := range m.clts {
+		cids = append(cids, cid)
+	}
+	m.RUnlock()
+	for _, cid := range cids {
+		m.removeClt(cid)
 	}
 }
 
-func (m *WsManager) routeEvent(packet *protocol.Packet, cid string) error {
+func (m *WsManager) getClt(cid string) (Connection, bool) {
+	m.RLock()
+	defer m.RUnlock()
 	conn, ok := m.clts[cid]
+	return conn, ok
+}
+
+func (m *WsManager) routeEvent(packet *protocol.Packet, cid string) error {
+	conn, ok := m.getClt(cid)
 	if !ok {
 		return errors.New("connection has broken")
 	}
@@ -152,9 +515,81 @@ func (m *WsManager) routeEvent(packet *protocol.Packet, cid string) error {
 	if !ok {
 		return errors.New("packet type not found")
 	}
+	if !m.allowRoute(packet, conn) {
+		return nil
+	}
 	return handler(packet, conn)
 }
 
+// allowFrame 连接读到一帧数据后、投递到 worker 之前按连接限流：超限的帧直接丢弃，
+// 里面的请求回错误响应，一分钟内多次超限踢下线，并按配置封禁 ip。已经被踢的连接在关闭前读到的帧都丢弃
+func (m *WsManager) allowFrame(conn Connection, frame []byte) bool {
+	if conn.GetSession().Kicked() {
+		return false
+	}
+	ok, kick := m.limiter.allowConn(conn.GetSession().Cid)
+	if ok {
+		return true
+	}
+	if kick {
+		m.rateLimitKick(conn)
+		return false
+	}
+	decoder := protocol.NewDecoder(0)
+	decoder.Feed(frame)
+	for {
+		packet, err := decoder.Next()
+		if err != nil || packet == nil {
+			break
+		}
+		route := ""
+		if packet.Type == protocol.Data {
+			message := packet.MessageBody()
+			route = message.Route
+			if message.Type == protocol.Request {
+				m.ErrorResponse(conn, message.ID, msError.RateLimited)
+			}
+		}
+		metrics.ObserveRateLimit(route)
+	}
+	return false
+}
+
+// allowRoute worker 解析出路由后，按路由单独配置的限流检查请求
+func (m *WsManager) allowRoute(packet *protocol.Packet, conn Connection) bool {
+	if conn.GetSession().Kicked() {
+		return false
+	}
+	if packet.Type != protocol.Data {
+		return true
+	}
+	message := packet.MessageBody()
+	ok, kick := m.limiter.allowRoute(conn.GetSession().Cid, message.Route)
+	if ok {
+		return true
+	}
+	metrics.ObserveRateLimit(message.Route)
+	if kick {
+		m.rateLimitKick(conn)
+		return false
+	}
+	if message.Type == protocol.Request {
+		m.ErrorResponse(conn, message.ID, msError.RateLimited)
+	}
+	return false
+}
+
+func (m *WsManager) rateLimitKick(conn Connection) {
+	session := conn.GetSession()
+	// 读 goroutine 和 worker 都可能判定踢下线，只踢一次、封禁一次
+	if !session.SetKicked() {
+		return
+	}
+	logs.Warn("client[%s] rate limit exceeded, uid=%s, ip=%s", session.Cid, session.GetUid(), session.Ip)
+	m.kick(conn, KickRateLimit)
+	m.blacklist.ban(session.Ip, time.Duration(m.RateLimit.BanTime)*time.Second)
+}
+
 func (m *WsManager) setupEventHandler() {
 	m.handlers[protocol.Handshake] = m.HandshakeHandler
 	m.handlers[protocol.HandshakeAck] = m.HandshakeAckHandler
@@ -165,11 +600,26 @@ func (m *WsManager) setupEventHandler() {
 
 func (m *WsManager) HandshakeHandler(packet *protocol.Packet, conn Connection) error {
 	logs.Info("receive handshake type: %v", packet.Type)
+	sys := m.Sys
+	session := conn.GetSession()
+	sys.Serializer = serializer.JSON
+	if body := packet.HandshakeBody(); body != nil {
+		// 客户端声明支持解压并且服务端开启了压缩，才对这个连接启用
+		if body.Sys.Compress && m.CompressThreshold > 0 {
+			sys.Compress = true
+			session.SetCompress(true)
+		}
+		// 不支持的序列化方式使用 json，客户端按响应中的 serializer 处理
+		if codec, ok := serializer.Get(body.Sys.Serializer); ok {
+			sys.Serializer = codec.Name()
+			session.SetSerializer(codec)
+		} else {
+			logs.Warn("client[%s] unsupported serializer: %s", session.Cid, body.Sys.Serializer)
+		}
+	}
 	resp := protocol.HandshakeResponse{
 		Code: 200,
-		Sys: protocol.Sys{
-			Heartbeat: 3,
-		},
+		Sys:  sys,
 	}
 	data, _ := json.Marshal(resp)
 	buf, err := protocol.Encode(packet.Type, data)
@@ -177,6 +627,12 @@ func (m *WsManager) HandshakeHandler(packet *protocol.Packet, conn Connection) e
 		logs.Error("encode packet err: %v", err)
 		return err
 	}
+	// 握手后客户端按 Sys.Heartbeat 间隔发送心跳，开始检测
+	cid := session.Cid
+	m.heartbeats.start(cid, func() {
+		logs.Warn("client[%s] heartbeat timeout", cid)
+		conn.Close()
+	})
 	return conn.SendMessage(buf)
 }
 
@@ -201,20 +657,40 @@ func (m *WsManager) HeartbeatHandler(packet *protocol.Packet, conn Connection) e
 func (m *WsManager) MessageHandler(packet *protocol.Packet, conn Connection) error {
 	logs.Info("receive message: %+v", packet.Body)
 	message := packet.MessageBody()
+	err := m.dispatchMessage(message, conn)
+	if err != nil && message.Type == protocol.Request {
+		// 请求失败也要用同一个 ID 响应，否则客户端会一直等待
+		m.ErrorResponse(conn, message.ID, err)
+	}
+	return err
+}
+
+func (m *WsManager) dispatchMessage(message *protocol.Message, conn Connection) error {
 	// routeStr 形如：connector.entryHandler.entry
 	routeStr := message.Route
 	routes := strings.Split(routeStr, ".")
 	if len(routes) != 3 {
-		return errors.New("route unsupported")
+		return msError.RouteUnsupported
+	}
+	// 后端收到的请求一定带 uid，handler 不需要再检查是否登录
+	if !m.authorized(conn.GetSession(), routeStr) {
+		return msError.Unauthorized
 	}
 	serverType := routes[0]
 	handlerMethod := fmt.Sprintf("%s.%s", routes[1], routes[2])
+	// 客户端按握手时选择的序列化方式发送，服务器内部统一使用 json
+	data, err := serializer.ToJSON(message.Data, conn.GetSession().Serializer())
+	if err != nil {
+		logs.Error("client message decode err: %v", err)
+		return msError.MessageDecodeFail
+	}
+	message.Data = data
 	connectorConfig := game.Conf.GetConnectorByServerType(serverType)
 	if connectorConfig != nil {
 		// 本地 connector 服务器处理
 		handler, ok := m.ConnectorHandlers[handlerMethod]
 		if !ok {
-			return errors.New("connector handler unsupported")
+			return msError.RouteUnsupported
 		}
 		data, err := handler(conn.GetSession(), message.Data)
 		if err != nil {
@@ -223,43 +699,86 @@ func (m *WsManager) MessageHandler(packet *protocol.Packet, conn Connection) err
 		marshal, _ := json.Marshal(data)
 		message.Type = protocol.Response
 		message.Data = marshal
-		body, err := protocol.MessageEncode(message)
-		if err != nil {
-			return err
-		}
-		resp, err := protocol.Encode(packet.Type, body)
+		resp, err := m.encodeData(message, conn.GetSession())
 		if err != nil {
 			return err
 		}
 		return conn.SendMessage(resp)
 	} else {
 		// nat handle
-		// 目标服务器 serverId
-		dst, err := m.selectDst(serverType)
-		if err != nil {
-			logs.Error("remote send msg selectDst err: %v", err)
-			return err
-		}
 		session := conn.GetSession()
+		// 目标服务器 serverId，房间相关的请求必须发往房间所在的节点
+		dst := m.selectRoomDst(routeStr, session, message.Data)
+		if dst == "" {
+			dst, err = m.selectDst(session, serverType)
+			if err != nil {
+				logs.Error("remote send msg selectDst err: %v", err)
+				return err
+			}
+		}
+		uid, sessionData := session.Snapshot()
 		msg := &remote.Msg{
 			Cid:         session.Cid,
-			Uid:         session.Uid,
+			Uid:         uid,
 			Src:         m.ServerId,
 			Dst:         dst,
 			Router:      handlerMethod,
 			Body:        message,
-			SessionData: session.data,
+			SessionData: sessionData,
+		}
+		data, err := remote.EncodeMsg(msg)
+		if err != nil {
+			logs.Error("remote encode msg err: %v", err)
+			return msError.RemoteSendFail
+		}
+		if message.Type == protocol.Request {
+			m.addPending(conn, message.ID, dst)
 		}
-		data, _ := json.Marshal(msg)
 		err = m.RemoteClt.SendMsg(dst, data)
 		if err != nil {
 			logs.Error("remote send msg err: %v", err)
-			return err
+			m.pending.done(session.Cid, message.ID)
+			return msError.RemoteSendFail
 		}
 	}
 	return nil
 }
 
+// 记录等待响应的 request，超过目标服务器的 HandleTimeOut 未响应时回超时错误
+func (m *WsManager) addPending(conn Connection, id uint, dst string) {
+	var timeout time.Duration
+	if serverConfig := game.Conf.GetServer(dst); serverConfig != nil {
+		timeout = time.Duration(serverConfig.HandleTimeOut) * time.Second
+	}
+	cid := conn.GetSession().Cid
+	m.pending.add(cid, id, timeout, func() {
+		logs.Error("request timeout, cid=%s, id=%d, dst=%s", cid, id, dst)
+		m.ErrorResponse(conn, id, msError.RequestTimeout)
+	})
+}
+
+// ErrorResponse 给 request 回一个带 ErrorMask 标识的响应，body 为 {"code": xxx, "msg": "..."}
+func (m *WsManager) ErrorResponse(conn Connection, id uint, err error) {
+	var msErr *msError.Error
+	if !errors.As(err, &msErr) {
+		msErr = msError.HandleFail
+	}
+	data, _ := json.Marshal(msErr)
+	buf, err := m.encodeData(&protocol.Message{
+		Type:  protocol.Response,
+		ID:    id,
+		Data:  data,
+		Error: true,
+	}, conn.GetSession())
+	if err != nil {
+		logs.Error("error response encode err: %v", err)
+		return
+	}
+	if err := conn.SendMessage(buf); err != nil {
+		logs.Error("error response send err: %v", err)
+	}
+}
+
 // 服务端会主动发起，所以服务端一般不用处理
 func (m *WsManager) KickHandler(packet *protocol.Packet, conn Connection) error {
 	logs.Info("receive kick message")
@@ -271,13 +790,14 @@ func (m *WsManager) remoteReadChanHandler() {
 		select {
 		case body, ok := <-m.RemoteReadChan:
 			if ok {
-				logs.Info("sub nats msg: %v", string(body))
 				var msg remote.Msg
-				if err := json.Unmarshal(body, &msg); err != nil {
+				if err := remote.DecodeMsg(body, &msg); err != nil {
 					logs.Error("nat remote message format err: %v", err)
 					continue
 				}
-				// 0 normal 推送至客户端；1 session 更新本地 session 相关数据，不推送
+				// 消息体可能是二进制编码，只记录解码后的路由信息
+				logs.Debug("sub nats msg: type=%d, src=%s, cid=%s, uid=%s, router=%s", msg.Type, msg.Src, msg.Cid, msg.Uid, msg.Router)
+				// 0 normal 推送至客户端；1 session 更新本地 session 相关数据，不推送；2 kick 重复登录踢人
 				switch msg.Type {
 				case remote.NormalType:
 					if msg.Body == nil {
@@ -285,6 +805,11 @@ func (m *WsManager) remoteReadChanHandler() {
 					}
 					switch msg.Body.Type {
 					case protocol.Request, protocol.Response:
+						if !m.pending.done(msg.Cid, msg.Body.ID) {
+							// 已经超时回过错误，或者连接已断开
+							logs.Warn("drop late response, cid=%s, id=%d", msg.Cid, msg.Body.ID)
+							continue
+						}
 						// 给客户端回消息都是 protocol.Response 类型
 						msg.Body.Type = protocol.Response
 						m.Response(&msg)
@@ -294,51 +819,119 @@ func (m *WsManager) remoteReadChanHandler() {
 				case remote.SessionType:
 					// 更新本地 session 相关数据
 					m.setSessionData(msg)
+				case remote.KickType:
+					m.remoteKick(&msg)
 				}
 			}
 		}
 	}
 }
 
-func (m *WsManager) selectDst(serverType string) (string, error) {
+func (m *WsManager) selectDst(session *Session, serverType string) (string, error) {
 	serverConfigs, ok := game.Conf.ServersConf.TypeServer[serverType]
 	if !ok {
-		return "", errors.New("no server found")
+		return "", msError.NoServerFound
 	}
-	// 随机选一个服务器，正常来说应该采用负载均衡算法：轮询、权重
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	index := rand.Intn(len(serverConfigs))
-	return serverConfigs[index].ID, nil
+	return m.Balancer.Select(session, serverType, serverConfigs)
 }
 
-func (m *WsManager) Response(r *remote.Msg) {
-	conn, ok := m.clts[r.Cid]
+// 查询房间目录，找不到房间时返回空，由调用方按 serverType 选择
+func (m *WsManager) selectRoomDst(route string, session *Session, body []byte) string {
+	if m.RoomDirectory == nil {
+		return ""
+	}
+	roomIdFunc, ok := m.RoomRoutes[route]
 	if !ok {
-		logs.Error("%s client down，uid=%s", r.Cid, r.Uid)
-		return
+		return ""
 	}
-	buf, err := protocol.MessageEncode(r.Body)
-	if err != nil {
-		logs.Error("response MessageEncode err: %v", err)
-		return
+	roomId := roomIdFunc(session, body)
+	if roomId == "" {
+		return ""
 	}
-	res, err := protocol.Encode(protocol.Data, buf)
+	serverId, ok, err := m.RoomDirectory.Lookup(roomId)
 	if err != nil {
-		logs.Error("response Encode err: %v", err)
-		return
+		logs.Error("room directory lookup err: %v, roomId=%s", err, roomId)
+		return ""
+	}
+	if !ok {
+		return ""
 	}
-	// 如果是推送的消息，可能会涉及到发送给多个 user
+	return serverId
+}
+
+func (m *WsManager) Response(r *remote.Msg) {
+	// 如果是推送的消息，可能会涉及到发送给多个 user，发起请求的连接不一定在本 connector 上
 	if r.Body.Type == protocol.Push {
-		for _, v := range m.clts {
-			if utils.Contains(r.PushUser, v.GetSession().Uid) {
-				v.SendMessage(res)
+		// 相同序列化方式、压缩设置的连接只编码一次
+		encoded := make(map[encoding][]byte)
+		for _, conn := range m.getCltsByUids(r.PushUser) {
+			session := conn.GetSession()
+			e := encoding{codec: session.Serializer().Name(), compress: session.Compress()}
+			res, ok := encoded[e]
+			if !ok {
+				var err error
+				if res, err = m.encodePush(r, session); err != nil {
+					logs.Error("response encode err: %v", err)
+					continue
+				}
+				encoded[e] = res
 			}
+			conn.SendPush(res)
 		}
 	} else {
+		conn, ok := m.getClt(r.Cid)
+		if !ok {
+			logs.Error("%s client down，uid=%s", r.Cid, r.Uid)
+			return
+		}
+		res, err := m.encodeData(r.Body, conn.GetSession())
+		if err != nil {
+			logs.Error("response encode err: %v", err)
+			return
+		}
 		conn.SendMessage(res)
 	}
 }
 
+// 连接握手时协商的消息体编码
+type encoding struct {
+	codec    string
+	compress bool
+}
+
+// encodeData 编码发给客户端的 Data 包，按连接握手时协商的结果转换序列化方式、压缩
+func (m *WsManager) encodeData(message *protocol.Message, session *Session) ([]byte, error) {
+	data, err := serializer.FromJSON(message.Data, session.Serializer())
+	if err != nil {
+		return nil, err
+	}
+	return m.encodeBody(message, data, session)
+}
+
+// encodePush 推送优先使用发出推送的节点按连接的序列化方式编码好的消息体，没有时才从 json 转换
+func (m *WsManager) encodePush(r *remote.Msg, session *Session) ([]byte, error) {
+	data, ok := r.Payloads[session.Serializer().Name()]
+	if !ok {
+		return m.encodeData(r.Body, session)
+	}
+	return m.encodeBody(r.Body, data, session)
+}
+
+// encodeBody 用已经按连接序列化方式编码的 data 替换消息体，按压缩设置编码成 Data 包
+func (m *WsManager) encodeBody(message *protocol.Message, data []byte, session *Session) ([]byte, error) {
+	threshold := 0
+	if session.Compress() {
+		threshold = m.CompressThreshold
+	}
+	msg := *message
+	msg.Data = data
+	body, err := protocol.MessageEncodeCompressed(&msg, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.Encode(protocol.Data, body)
+}
+
 func (m *WsManager) remotePushChanHandler() {
 	for {
 		select {
@@ -365,10 +958,12 @@ func (m *WsManager) setSessionData(msg remote.Msg) {
 
 func NewWsManager() *WsManager {
 	return &WsManager{
-		cltReadChan:    make(chan *MsgPack, 1024),
 		clts:           make(map[string]Connection),
 		handlers:       make(map[protocol.PackageType]EventHandler),
 		RemoteReadChan: make(chan []byte, 1024),
 		RemotePushChan: make(chan *remote.Msg, 1024),
+		Balancer:       &balancer.RandomBalancer{},
+		pending:        newPendingRequests(),
+		uidClts:        make(map[string]map[string]Connection),
 	}
 }
@@ -2,16 +2,29 @@ package node
 
 import (
 	"common/logs"
+	"context"
 	"encoding/json"
+	"fmt"
+	"framework/directory"
+	"framework/game"
+	"framework/msError"
+	"framework/protocol"
 	"framework/remote"
+	"time"
 )
 
 // 处理游戏实际逻辑的服务
 type App struct {
-	remoteClt remote.Client
-	readChan  chan []byte
-	writeChan chan *remote.Msg
-	handlers  LogicHandler
+	serverId    string
+	remoteClt   remote.Client
+	newRemote   remote.ClientFactory // 默认 NATS，单进程部署/测试时可换成 MemoryBus
+	readChan    chan []byte
+	writeChan   chan *remote.Msg
+	handlers    LogicHandler
+	middlewares []Middleware       // 作用于所有路由的 middleware
+	executor    *executor          // 同一房间/用户的消息串行，不同房间/用户并发处理
+	presence    directory.Presence // 用户所在的 connector，推送时按 connector 分发
+	roomRoutes  map[string]string  // 路由 -> 请求体中房间号的字段，这些请求按房间串行
 }
 
 func Default() *App {
@@ -19,11 +32,25 @@ func Default() *App {
 		readChan:  make(chan []byte, 1024),
 		writeChan: make(chan *remote.Msg, 1024),
 		handlers:  make(LogicHandler),
+		newRemote: remote.NatsClientFactory,
 	}
 }
 
 func (a *App) Run(serverId string) error {
-	a.remoteClt = remote.NewNatsClient(serverId, a.readChan)
+	a.serverId = serverId
+	var maxRunRoutineNum int
+	var handleTimeOut time.Duration
+	if serverConfig := game.Conf.GetServer(serverId); serverConfig != nil {
+		maxRunRoutineNum = serverConfig.MaxRunRoutineNum
+		handleTimeOut = time.Duration(serverConfig.HandleTimeOut) * time.Second
+	}
+	a.executor = newExecutor(maxRunRoutineNum, handleTimeOut)
+	for route, h := range a.handlers {
+		a.handlers[route] = Chain(route, h, a.middlewares...)
+	}
+	a.remoteClt = a.newRemote(serverId, a.readChan)
+	// 其他节点的同步调用也由同一个 LogicHandler 处理
+	a.remoteClt.HandleRequest(a.handleRequest)
 	err := a.remoteClt.Run()
 	if err != nil {
 		return err
@@ -39,36 +66,132 @@ func (a *App) readChanMsg() {
 		select {
 		case data := <-a.readChan:
 			var req remote.Msg
-			json.Unmarshal(data, &req)
-			// 根据 路由消息，分发给对应的 handler 处理
-			router := req.Router
-			session := remote.NewSession(a.remoteClt, &req)
-			session.setData(req.SessionData)
-			handlerFunc, ok := a.handlers[router]
-			if !ok || handlerFunc == nil {
+			if err := remote.DecodeMsg(data, &req); err != nil {
+				logs.Error("nat remote message format err: %v", err)
 				continue
 			}
+			a.executor.submit(a.dispatchKey(&req), func(ctx context.Context) {
+				a.handle(ctx, &req)
+			})
+		}
+	}
+}
 
-			body := req.Body
-			handlerResp := handlerFunc(session, body.Data)
+// 根据 路由消息，分发给对应的 handler 处理
+func (a *App) handle(ctx context.Context, req *remote.Msg) {
+	handlerResp, ok := a.invoke(req)
+	if !ok {
+		return
+	}
+	if ctx.Err() != nil {
+		// 已经超时，客户端不再等待这个响应
+		logs.Error("drop timeout response, router=%s, uid=%s", req.Router, req.Uid)
+		return
+	}
 
-			var respBytes []byte
-			if handlerResp != nil {
-				respBytes, _ = json.Marshal(handlerResp)
-			}
-			body.Data = respBytes
-
-			resp := &remote.Msg{
-				Cid:  req.Cid,
-				Uid:  req.Uid,
-				Src:  req.Dst,
-				Dst:  req.Src,
-				Body: body,
-			}
+	body := req.Body
+	var respBytes []byte
+	if handlerResp != nil {
+		respBytes, _ = json.Marshal(handlerResp)
+	}
+	body.Data = respBytes
+	// 和 connector 的 ErrorResponse 一样带上 ErrorMask，包括 Recover 返回的 HandleFail
+	_, body.Error = handlerResp.(*msError.Error)
+
+	resp := &remote.Msg{
+		Cid:  req.Cid,
+		Uid:  req.Uid,
+		Src:  req.Dst,
+		Dst:  req.Src,
+		Body: body,
+	}
+
+	a.writeChan <- resp
+}
+
+func (a *App) invoke(req *remote.Msg) (any, bool) {
+	session := remote.NewSession(a.remoteClt, req, a.presence)
+	session.SetData(req.SessionData)
+	handlerFunc, ok := a.handlers[req.Router]
+	if !ok || handlerFunc == nil || req.Body == nil {
+		return nil, false
+	}
+	return handlerFunc(session, req.Body.Data), true
+}
 
-			a.writeChan <- resp
+// 处理其他节点的同步调用，和普通消息一样按房间/用户排队执行，handler 返回 *msError.Error 时响应带上 Error 标识
+func (a *App) handleRequest(data []byte) []byte {
+	var req remote.Msg
+	if err := remote.DecodeMsg(data, &req); err != nil {
+		logs.Error("nat remote request format err: %v", err)
+		return nil
+	}
+	done := make(chan *remote.Msg, 1)
+	a.executor.submit(a.dispatchKey(&req), func(ctx context.Context) {
+		handlerResp, ok := a.invoke(&req)
+		if !ok {
+			// 路由不存在，Body 为空
+			done <- &remote.Msg{Src: req.Dst, Dst: req.Src}
+			return
+		}
+		body := &protocol.Message{
+			Type:  protocol.Response,
+			Route: req.Router,
+		}
+		if handlerResp != nil {
+			body.Data, _ = json.Marshal(handlerResp)
 		}
+		_, body.Error = handlerResp.(*msError.Error)
+		done <- &remote.Msg{
+			Cid:  req.Cid,
+			Uid:  req.Uid,
+			Src:  req.Dst,
+			Dst:  req.Src,
+			Body: body,
+		}
+	})
+	resp, err := remote.EncodeMsg(<-done)
+	if err != nil {
+		logs.Error("nat remote response encode err: %v", err)
+		return nil
 	}
+	return resp
+}
+
+// Call 同步调用 serverType 类型节点上的 route，不在 handler 中时使用（handler 中用 session.Call 可以带上用户信息）
+func (a *App) Call(ctx context.Context, serverType, route string, req any, resp any) error {
+	return remote.Call(ctx, a.remoteClt, &remote.Msg{Dst: a.serverId}, serverType, route, req, resp)
+}
+
+// 在房间中的消息按房间串行，保证房间状态的修改有序；进入房间的请求按请求体中的房间号串行；否则按用户串行
+func (a *App) dispatchKey(req *remote.Msg) string {
+	if roomId, ok := req.SessionData["roomId"]; ok && roomId != nil {
+		return fmt.Sprintf("room:%v", roomId)
+	}
+	if roomId := a.bodyRoomId(req); roomId != "" {
+		return "room:" + roomId
+	}
+	if req.Uid != "" {
+		return "uid:" + req.Uid
+	}
+	return "cid:" + req.Cid
+}
+
+// 请求体中的房间号，路由没有注册为房间路由或者解析不出来时返回空
+func (a *App) bodyRoomId(req *remote.Msg) string {
+	field, ok := a.roomRoutes[req.Router]
+	if !ok || req.Body == nil {
+		return ""
+	}
+	var body map[string]any
+	if err := json.Unmarshal(req.Body.Data, &body); err != nil {
+		return ""
+	}
+	v, ok := body[field]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
 }
 
 func (a *App) writeChanMsg() {
@@ -76,8 +199,12 @@ func (a *App) writeChanMsg() {
 		select {
 		case resp, ok := <-a.writeChan:
 			if ok {
-				respBytes, _ := json.Marshal(resp)
-				err := a.remoteClt.SendMsg(resp.Dst, respBytes)
+				respBytes, err := remote.EncodeMsg(resp)
+				if err != nil {
+					logs.Error("app remote encode msg err: %v", err)
+					continue
+				}
+				err = a.remoteClt.SendMsg(resp.Dst, respBytes)
 				if err != nil {
 					logs.Error("app remote send msg err: %v", err)
 				}
@@ -95,3 +222,24 @@ func (a *App) Close() {
 func (a *App) RegisterHandler(handler LogicHandler) {
 	a.handlers = handler
 }
+
+// SetRemoteClient 替换 remote.Client 的实现，需要在 Run 之前调用
+func (a *App) SetRemoteClient(factory remote.ClientFactory) {
+	a.newRemote = factory
+}
+
+// SetPresence 设置用户在线目录，多个 connector 时推送才能送达其他 connector 上的用户
+func (a *App) SetPresence(presence directory.Presence) {
+	a.presence = presence
+}
+
+// RegisterRoomRoutes 房间号在请求体中的路由，比如 unionHandler.joinRoom 的 roomID。
+// 这些请求和房间内的请求按同一个房间号串行，多个用户同时进入同一个房间时不会并发修改房间
+func (a *App) RegisterRoomRoutes(routes map[string]string) {
+	a.roomRoutes = routes
+}
+
+// Use 添加作用于所有路由的 middleware，在 Run 时包装到每个 handler 外层
+func (a *App) Use(middlewares ...Middleware) {
+	a.middlewares = append(a.middlewares, middlewares...)
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"common/biz"
+	"context"
+	"errors"
+	"framework/msError"
+	"framework/protocol"
+	"framework/remote"
+	"testing"
+)
+
+func TestDispatchKey(t *testing.T) {
+	a := Default()
+	a.RegisterRoomRoutes(map[string]string{"unionHandler.joinRoom": "roomID"})
+	tests := []struct {
+		name string
+		req  *remote.Msg
+		want string
+	}{
+		{"sessionRoom", &remote.Msg{Uid: "1", Router: "gameHandler.roomMessageNotify", SessionData: map[string]any{"roomId": "100001"}}, "room:100001"},
+		// 进入房间时 session 中还没有房间号，按请求体中的房间号串行
+		{"bodyRoom", &remote.Msg{Uid: "2", Router: "unionHandler.joinRoom", Body: &protocol.Message{Data: []byte(`{"roomID":"100001"}`)}}, "room:100001"},
+		{"badBody", &remote.Msg{Uid: "2", Router: "unionHandler.joinRoom", Body: &protocol.Message{Data: []byte(`x`)}}, "uid:2"},
+		{"user", &remote.Msg{Uid: "3", Router: "unionHandler.createRoom", Body: &protocol.Message{Data: []byte(`{"roomID":"100001"}`)}}, "uid:3"},
+		{"conn", &remote.Msg{Cid: "c1", Router: "userHandler.login"}, "cid:c1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.dispatchKey(tt.req); got != tt.want {
+				t.Fatalf("dispatchKey() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// App.Call 没有 uid，调用需要登录的路由时要返回 Auth 的错误，而不是把失败的响应解码到 resp
+func TestCallAuthRoute(t *testing.T) {
+	setupOnce.Do(setupLog)
+	bus := remote.NewMemoryBus()
+	hall := Default()
+	hall.SetRemoteClient(bus.Factory())
+	handlers := make(LogicHandler)
+	handlers.Handle("userHandler.permission", func(session *remote.Session, msg []byte) any {
+		return map[string]any{"uid": session.GetUid()}
+	}, Auth(biz.InvalidUsers))
+	hall.RegisterHandler(handlers)
+	if err := hall.Run("hall-test"); err != nil {
+		t.Fatal(err)
+	}
+	defer hall.Close()
+
+	caller := Default()
+	caller.SetRemoteClient(bus.Factory())
+	if err := caller.Run("game-test"); err != nil {
+		t.Fatal(err)
+	}
+	defer caller.Close()
+	var resp map[string]any
+	err := caller.Call(context.Background(), "hall", "userHandler.permission", nil, &resp)
+	var msErr *msError.Error
+	if !errors.As(err, &msErr) || msErr.Code != biz.InvalidUsers.Code {
+		t.Fatalf("expected InvalidUsers, got %v, resp=%v", err, resp)
+	}
+}
@@ -0,0 +1,88 @@
+package node
+
+import (
+	"common/logs"
+	"context"
+	"sync"
+	"time"
+)
+
+// 默认最大并发数，ServersConfig.MaxRunRoutineNum 未配置时使用
+const defaultMaxRunRoutineNum = 1024
+
+// executor 按 key 串行、不同 key 并发地执行任务
+// 同一个 key（房间或用户）的任务按投递顺序执行，同时运行的 key 数量不超过 maxRunRoutineNum，
+// 达到上限时 submit 会阻塞，从而让 NATS 消息在 readChan 中排队
+type executor struct {
+	sync.Mutex
+	sem     chan struct{}
+	queues  map[string][]func(ctx context.Context)
+	timeout time.Duration
+}
+
+func newExecutor(maxRunRoutineNum int, timeout time.Duration) *executor {
+	if maxRunRoutineNum <= 0 {
+		maxRunRoutineNum = defaultMaxRunRoutineNum
+	}
+	return &executor{
+		sem:     make(chan struct{}, maxRunRoutineNum),
+		queues:  make(map[string][]func(ctx context.Context)),
+		timeout: timeout,
+	}
+}
+
+func (e *executor) submit(key string, task func(ctx context.Context)) {
+	e.Lock()
+	if q, ok := e.queues[key]; ok {
+		// 这个 key 已经有 goroutine 在处理，排在它后面
+		e.queues[key] = append(q, task)
+		e.Unlock()
+		return
+	}
+	e.queues[key] = []func(ctx context.Context){task}
+	e.Unlock()
+	e.sem <- struct{}{}
+	go e.drain(key)
+}
+
+func (e *executor) drain(key string) {
+	defer func() {
+		<-e.sem
+	}()
+	for {
+		e.Lock()
+		q := e.queues[key]
+		if len(q) == 0 {
+			delete(e.queues, key)
+			e.Unlock()
+			return
+		}
+		task := q[0]
+		e.queues[key] = q[1:]
+		e.Unlock()
+		e.run(key, task)
+	}
+}
+
+// 超过 timeout 时 ctx 取消，任务通过 ctx 得知响应已作废；但任务可能还在修改房间状态，
+// 所以仍然等它真正返回后才执行该 key 的下一个任务，并发名额也一直占用到它返回
+func (e *executor) run(key string, task func(ctx context.Context)) {
+	if e.timeout <= 0 {
+		task(context.Background())
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		task(ctx)
+	}()
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+		logs.Error("handle timeout, key=%s, timeout=%v", key, e.timeout)
+	}
+	<-done
+}
@@ -0,0 +1,91 @@
+package node
+
+import (
+	"common/config"
+	"common/logs"
+	"context"
+	"framework/game"
+	"sync"
+	"testing"
+	"time"
+)
+
+var setupOnce sync.Once
+
+func setupLog() {
+	config.Conf = &config.Config{}
+	logs.InitLog("test")
+	game.Conf = &game.Config{
+		ServersConf: game.ServersConf{
+			TypeServer: map[string][]*game.ServersConfig{
+				"hall": {{ID: "hall-test", ServerType: "hall", RPCTimeOut: 1}},
+			},
+		},
+	}
+}
+
+// 超时的任务返回之前，同一个 key 的下一个任务不能开始
+func TestExecutorTimeoutKeepsKeyOrder(t *testing.T) {
+	setupOnce.Do(setupLog)
+	e := newExecutor(8, 20*time.Millisecond)
+	var mu sync.Mutex
+	var events []string
+	record := func(s string) {
+		mu.Lock()
+		events = append(events, s)
+		mu.Unlock()
+	}
+	firstCtxErr := make(chan error, 1)
+	done := make(chan struct{})
+	e.submit("room:1", func(ctx context.Context) {
+		record("slow start")
+		time.Sleep(100 * time.Millisecond)
+		firstCtxErr <- ctx.Err()
+		record("slow end")
+	})
+	e.submit("room:1", func(ctx context.Context) {
+		record("next")
+		close(done)
+	})
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("next task not executed")
+	}
+	if err := <-firstCtxErr; err == nil {
+		t.Fatal("slow task ctx should be canceled after timeout")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"slow start", "slow end", "next"}
+	for i := range want {
+		if i >= len(events) || events[i] != want[i] {
+			t.Fatalf("unexpected order: %v", events)
+		}
+	}
+}
+
+// 超时的任务返回之前一直占用并发名额
+func TestExecutorTimeoutHoldsSlot(t *testing.T) {
+	setupOnce.Do(setupLog)
+	e := newExecutor(1, 20*time.Millisecond)
+	slowDone := make(chan struct{})
+	e.submit("room:1", func(ctx context.Context) {
+		time.Sleep(100 * time.Millisecond)
+		close(slowDone)
+	})
+	started := make(chan struct{})
+	go e.submit("room:2", func(ctx context.Context) {
+		close(started)
+	})
+	select {
+	case <-started:
+		select {
+		case <-slowDone:
+		default:
+			t.Fatal("second key started while the timed out task was still running")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("second key not executed")
+	}
+}
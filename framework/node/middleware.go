@@ -0,0 +1,103 @@
+package node
+
+import (
+	"common/logs"
+	"common/metrics"
+	"framework/msError"
+	"framework/remote"
+	"reflect"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware 包装 handler，route 为注册时的路由，形如：unionHandler.createRoom
+type Middleware func(route string, next HandlerFunc) HandlerFunc
+
+// Handle 注册路由，middlewares 只作用于这个路由，按传入顺序由外到内执行
+func (l LogicHandler) Handle(route string, handler HandlerFunc, middlewares ...Middleware) {
+	l[route] = Chain(route, handler, middlewares...)
+}
+
+func Chain(route string, handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](route, handler)
+	}
+	return handler
+}
+
+// Recover handler panic 时记录堆栈并返回 HandleFail，避免整个进程退出
+func Recover() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *remote.Session, msg []byte) (resp any) {
+			defer func() {
+				if r := recover(); r != nil {
+					logs.Error("handler panic, route=%s, uid=%s, err=%v\n%s", route, session.GetUid(), r, debug.Stack())
+					resp = msError.HandleFail
+				}
+			}()
+			return next(session, msg)
+		}
+	}
+}
+
+// Auth 要求用户已经登录（session 中有 uid），否则返回 fail。
+// fail 是 *msError.Error，响应带 ErrorMask，客户端和 remote.Call 的调用方都能识别为失败
+func Auth(fail *msError.Error) Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *remote.Session, msg []byte) any {
+			if len(session.GetUid()) <= 0 {
+				return fail
+			}
+			return next(session, msg)
+		}
+	}
+}
+
+// Logger 记录请求和耗时
+func Logger() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *remote.Session, msg []byte) any {
+			start := time.Now()
+			resp := next(session, msg)
+			logs.Info("handle route=%s, uid=%s, cost=%v", route, session.GetUid(), time.Since(start))
+			return resp
+		}
+	}
+}
+
+// Failure 业务响应实现该接口时，Failed 返回 true 也算失败，比如 code 不为 0 的 common.Result
+type Failure interface {
+	Failed() bool
+}
+
+// isFailed handler 返回 *msError.Error 或者失败的业务响应
+func isFailed(resp any) bool {
+	if _, ok := resp.(*msError.Error); ok {
+		return true
+	}
+	f, ok := resp.(Failure)
+	if !ok {
+		return false
+	}
+	// *common.Result(nil) 调用值接收者的方法会 panic
+	if v := reflect.ValueOf(resp); v.Kind() == reflect.Pointer && v.IsNil() {
+		return false
+	}
+	return f.Failed()
+}
+
+// Metrics 统计调用次数、失败次数和耗时，panic 也算失败
+func Metrics() Middleware {
+	return func(route string, next HandlerFunc) HandlerFunc {
+		return func(session *remote.Session, msg []byte) any {
+			start := time.Now()
+			failed := true
+			defer func() {
+				metrics.ObserveHandler(route, time.Since(start), failed)
+			}()
+			resp := next(session, msg)
+			failed = isFailed(resp)
+			return resp
+		}
+	}
+}
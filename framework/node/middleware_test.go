@@ -0,0 +1,34 @@
+package node
+
+import (
+	"common"
+	"common/biz"
+	"framework/msError"
+	"testing"
+)
+
+func TestIsFailed(t *testing.T) {
+	fail := common.FailNoCtx(biz.RoomNotExist)
+	ok := common.SuccessNoCtx(nil)
+	tests := []struct {
+		name string
+		resp any
+		want bool
+	}{
+		{"nil", nil, false},
+		{"msError", msError.HandleFail, true},
+		{"resultFail", fail, true},
+		{"resultFailPtr", &fail, true},
+		{"resultOk", ok, false},
+		{"resultOkPtr", &ok, false},
+		{"nilResultPtr", (*common.Result)(nil), false},
+		{"other", map[string]any{"code": 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFailed(tt.resp); got != tt.want {
+				t.Fatalf("isFailed(%v) = %v, want %v", tt.resp, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package node
+
+import (
+	"common/biz"
+	"encoding/json"
+	"framework/msError"
+	"framework/remote"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Validator 请求参数实现该接口时，解码后自动校验，返回的错误直接响应给客户端
+type Validator interface {
+	Validate() *msError.Error
+}
+
+// TypedHandlerFunc 请求体自动解码为 Req，返回值自动编码；出错时返回 *msError.Error，客户端收到 {"code": xxx, "msg": "..."}
+type TypedHandlerFunc[Req any, Resp any] func(session *remote.Session, req *Req) (*Resp, *msError.Error)
+
+// Typed 把 TypedHandlerFunc 转换成 HandlerFunc
+func Typed[Req any, Resp any](fn TypedHandlerFunc[Req, Resp]) HandlerFunc {
+	return func(session *remote.Session, msg []byte) any {
+		req := new(Req)
+		if fail := decodeRequest(msg, req); fail != nil {
+			return fail
+		}
+		resp, err := fn(session, req)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return nil
+		}
+		return resp
+	}
+}
+
+// Register 注册一个 TypedHandlerFunc 路由
+func Register[Req any, Resp any](l LogicHandler, route string, fn TypedHandlerFunc[Req, Resp], middlewares ...Middleware) {
+	l.Handle(route, Typed(fn), middlewares...)
+}
+
+var (
+	sessionType = reflect.TypeOf((*remote.Session)(nil))
+	bytesType   = reflect.TypeOf([]byte(nil))
+	anyType     = reflect.TypeOf((*any)(nil)).Elem()
+	errorType   = reflect.TypeOf((*msError.Error)(nil))
+)
+
+// RegisterMethods 把 handler 所有导出方法注册为 <handlerName>.<method> 路由，方法名首字母小写，比如 unionHandler.createRoom
+// 支持两种方法签名，其他签名的方法会被忽略：
+//
+//	func(session *remote.Session, msg []byte) any
+//	func(session *remote.Session, req *Req) (*Resp, *msError.Error)
+func RegisterMethods(l LogicHandler, handlerName string, handler any, middlewares ...Middleware) {
+	v := reflect.ValueOf(handler)
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		h, ok := methodHandler(v.Method(i))
+		if !ok {
+			continue
+		}
+		l.Handle(handlerName+"."+lowerFirst(method.Name), h, middlewares...)
+	}
+}
+
+func methodHandler(m reflect.Value) (HandlerFunc, bool) {
+	mt := m.Type()
+	if mt.NumIn() != 2 || mt.In(0) != sessionType {
+		return nil, false
+	}
+	if mt.In(1) == bytesType && mt.NumOut() == 1 && mt.Out(0) == anyType {
+		return m.Interface().(func(*remote.Session, []byte) any), true
+	}
+	if mt.In(1).Kind() != reflect.Pointer || mt.NumOut() != 2 || mt.Out(0).Kind() != reflect.Pointer || mt.Out(1) != errorType {
+		return nil, false
+	}
+	reqType := mt.In(1).Elem()
+	return func(session *remote.Session, msg []byte) any {
+		req := reflect.New(reqType)
+		if fail := decodeRequest(msg, req.Interface()); fail != nil {
+			return fail
+		}
+		out := m.Call([]reflect.Value{reflect.ValueOf(session), req})
+		if !out[1].IsNil() {
+			return out[1].Interface()
+		}
+		if out[0].IsNil() {
+			return nil
+		}
+		return out[0].Interface()
+	}, true
+}
+
+// 空请求体解码为零值；解码失败和 Validate 失败一样返回 *msError.Error，客户端收到的都是带 ErrorMask 的 RequestDataError
+func decodeRequest(msg []byte, req any) *msError.Error {
+	if len(msg) > 0 {
+		if err := json.Unmarshal(msg, req); err != nil {
+			return biz.RequestDataError
+		}
+	}
+	if v, ok := req.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
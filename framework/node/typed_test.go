@@ -0,0 +1,108 @@
+package node
+
+import (
+	"common"
+	"common/biz"
+	"errors"
+	"framework/msError"
+	"framework/remote"
+	"testing"
+)
+
+type echoReq struct {
+	Name string `json:"name"`
+}
+
+func (r *echoReq) Validate() *msError.Error {
+	if r.Name == "invalid" {
+		return biz.RequestDataError
+	}
+	return nil
+}
+
+type echoResp struct {
+	Name string `json:"name"`
+}
+
+var errEcho = msError.NewError(100, errors.New("echo fail"))
+
+type echoHandler struct{}
+
+func (h *echoHandler) Echo(session *remote.Session, req *echoReq) (*echoResp, *msError.Error) {
+	if req.Name == "fail" {
+		return nil, errEcho
+	}
+	return &echoResp{Name: req.Name}, nil
+}
+
+func (h *echoHandler) Raw(session *remote.Session, msg []byte) any {
+	return string(msg)
+}
+
+// 以下签名不符合要求，不会注册
+func (h *echoHandler) NoSession(req *echoReq) (*echoResp, *msError.Error) { return nil, nil }
+func (h *echoHandler) ValueReq(session *remote.Session, req echoReq) (*echoResp, *msError.Error) {
+	return nil, nil
+}
+func (h *echoHandler) PlainError(session *remote.Session, req *echoReq) (*echoResp, error) {
+	return nil, nil
+}
+func (h *echoHandler) RawString(session *remote.Session, msg []byte) string { return "" }
+
+func TestRegisterMethods(t *testing.T) {
+	l := make(LogicHandler)
+	RegisterMethods(l, "echoHandler", &echoHandler{})
+	if len(l) != 2 {
+		t.Fatalf("unexpected routes: %v", l)
+	}
+	for _, route := range []string{"echoHandler.echo", "echoHandler.raw"} {
+		if _, ok := l[route]; !ok {
+			t.Fatalf("route %s not registered", route)
+		}
+	}
+	if resp := l["echoHandler.raw"](nil, []byte("hi")); resp != "hi" {
+		t.Fatalf("unexpected raw response: %v", resp)
+	}
+	testTypedHandler(t, l["echoHandler.echo"])
+}
+
+func TestTyped(t *testing.T) {
+	h := &echoHandler{}
+	testTypedHandler(t, Typed(h.Echo))
+}
+
+func testTypedHandler(t *testing.T, handler HandlerFunc) {
+	tests := []struct {
+		name string
+		msg  string
+		want any
+	}{
+		{"ok", `{"name":"a"}`, &echoResp{Name: "a"}},
+		{"empty", ``, &echoResp{}},
+		// 解码失败和校验失败一样返回 RequestDataError，响应带 ErrorMask
+		{"decodeFail", `{"name":1}`, biz.RequestDataError},
+		{"validateFail", `{"name":"invalid"}`, biz.RequestDataError},
+		{"handlerFail", `{"name":"fail"}`, errEcho},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handler(nil, []byte(tt.msg))
+			switch want := tt.want.(type) {
+			case *echoResp:
+				got, ok := resp.(*echoResp)
+				if !ok || *got != *want {
+					t.Fatalf("unexpected response: %#v", resp)
+				}
+			case common.Result:
+				got, ok := resp.(common.Result)
+				if !ok || got.Code != want.Code {
+					t.Fatalf("unexpected response: %#v", resp)
+				}
+			default:
+				if resp != tt.want {
+					t.Fatalf("unexpected response: %#v, want %#v", resp, tt.want)
+				}
+			}
+		})
+	}
+}
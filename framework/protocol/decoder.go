@@ -0,0 +1,114 @@
+package protocol
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrPacketTruncated = errors.New("packet truncated")
+	ErrPacketTrailing  = errors.New("unexpected data after packet")
+	ErrPacketTooLarge  = errors.New("packet too large")
+	ErrPacketType      = errors.New("invalid packet type")
+)
+
+// Decoder 流式解码：pomelo 客户端可能在一帧里发送多个 packet，TCP 连接中的 packet 也没有边界，
+// Feed 追加读到的数据，Next 依次取出完整的 packet
+type Decoder struct {
+	buf     []byte
+	maxSize int
+	err     error
+}
+
+// NewDecoder maxSize 为单个 packet body 的最大长度，<= 0 时使用协议允许的最大长度
+func NewDecoder(maxSize int) *Decoder {
+	if maxSize <= 0 || maxSize >= MaxPacketSize {
+		maxSize = MaxPacketSize - 1
+	}
+	return &Decoder{
+		maxSize: maxSize,
+	}
+}
+
+func (d *Decoder) Feed(data []byte) {
+	d.buf = append(d.buf, data...)
+}
+
+// Next 返回下一个完整的 packet，数据不够一个 packet 时返回 nil, nil。
+// packet 的 body 解码失败（比如 message 格式错误）时和 Decode 一样返回部分解析的 packet 和错误，不影响后面的 packet；
+// 包头错误（类型非法、超长）时之后的数据找不到 packet 边界，返回 nil 和错误，之后一直返回这个错误
+func (d *Decoder) Next() (*Packet, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.buf) < HeaderLen {
+		return nil, nil
+	}
+	t, n, err := decodeHeader(d.buf, d.maxSize)
+	if err != nil {
+		d.err = err
+		d.buf = nil
+		return nil, err
+	}
+	if len(d.buf) < HeaderLen+n {
+		return nil, nil
+	}
+	// 拷贝一份，解码出的 Data 不引用 buf，buf 之后还会追加数据
+	body := make([]byte, n)
+	copy(body, d.buf[HeaderLen:HeaderLen+n])
+	d.buf = d.buf[HeaderLen+n:]
+	if len(d.buf) == 0 {
+		d.buf = nil
+	}
+	return decodeBody(t, body)
+}
+
+// Buffered 还没有组成完整 packet 的字节数
+func (d *Decoder) Buffered() int {
+	return len(d.buf)
+}
+
+// DecodeAll 解码 buf 中的所有 packet，buf 必须正好由完整的 packet 组成，任何一个 packet 出错都返回错误
+func DecodeAll(buf []byte) ([]*Packet, error) {
+	d := NewDecoder(0)
+	d.Feed(buf)
+	var packets []*Packet
+	for {
+		p, err := d.Next()
+		if err != nil {
+			return packets, err
+		}
+		if p == nil {
+			break
+		}
+		packets = append(packets, p)
+	}
+	if d.Buffered() > 0 {
+		return packets, ErrPacketTruncated
+	}
+	return packets, nil
+}
+
+// ReadPacket 从字节流（TCP）中读取一个完整的 packet，返回包头 + body，maxSize 为 body 的最大长度
+func ReadPacket(r io.Reader, maxSize int) ([]byte, error) {
+	if maxSize <= 0 || maxSize >= MaxPacketSize {
+		maxSize = MaxPacketSize - 1
+	}
+	header := make([]byte, HeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	_, n, err := decodeHeader(header, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, HeaderLen+n)
+	copy(buf, header)
+	if _, err := io.ReadFull(r, buf[HeaderLen:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrPacketTruncated
+		}
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func encodeRequest(t testing.TB, id uint, route string, data string) []byte {
+	body, err := MessageEncode(&Message{Type: Request, ID: id, Route: route, Data: []byte(data)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := Encode(Data, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestDecodeAllMultiPacket(t *testing.T) {
+	heartbeat, _ := Encode(Heartbeat, nil)
+	var frame []byte
+	frame = append(frame, encodeRequest(t, 1, "connector.entryHandler.entry", `{"token":"x"}`)...)
+	frame = append(frame, heartbeat...)
+	frame = append(frame, encodeRequest(t, 2, "game.unionHandler.joinRoom", `{"roomID":"1"}`)...)
+
+	packets, err := DecodeAll(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 3 || packets[1].Type != Heartbeat {
+		t.Fatalf("unexpected packets: %+v", packets)
+	}
+	if m := packets[2].MessageBody(); m.ID != 2 || m.Route != "game.unionHandler.joinRoom" {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	// 截断
+	if _, err := DecodeAll(frame[:len(frame)-1]); !errors.Is(err, ErrPacketTruncated) {
+		t.Fatalf("expected truncated error, got %v", err)
+	}
+	if _, err := Decode(frame); !errors.Is(err, ErrPacketTrailing) {
+		t.Fatalf("expected trailing error, got %v", err)
+	}
+}
+
+func TestDecoderStream(t *testing.T) {
+	frame := encodeRequest(t, 7, "connector.entryHandler.entry", `{}`)
+	d := NewDecoder(0)
+	// 一次只喂一个字节，直到最后一个字节才得到完整的 packet
+	for i := 0; i < len(frame)-1; i++ {
+		d.Feed(frame[i : i+1])
+		if p, err := d.Next(); p != nil || err != nil {
+			t.Fatalf("unexpected packet at %d: %v %v", i, p, err)
+		}
+	}
+	d.Feed(frame[len(frame)-1:])
+	p, err := d.Next()
+	if err != nil || p == nil || p.MessageBody().ID != 7 {
+		t.Fatalf("unexpected packet: %v %v", p, err)
+	}
+}
+
+func TestDecoderTooLarge(t *testing.T) {
+	frame := encodeRequest(t, 1, "connector.entryHandler.entry", `{"token":"xxxxxxxxxxxxxxxx"}`)
+	d := NewDecoder(8)
+	d.Feed(frame)
+	if _, err := d.Next(); !errors.Is(err, ErrPacketTooLarge) {
+		t.Fatalf("expected too large error, got %v", err)
+	}
+	// 之后一直返回错误
+	d.Feed(frame)
+	if _, err := d.Next(); !errors.Is(err, ErrPacketTooLarge) {
+		t.Fatalf("expected sticky error, got %v", err)
+	}
+}
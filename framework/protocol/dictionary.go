@@ -0,0 +1,107 @@
+package protocol
+
+import (
+	"common/logs"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 路由字典：route <-> uint16 code，由服务端根据注册的 handler 路由和推送路由生成，
+// 握手时通过 Sys.Dict 下发给客户端，之后双方都用 2 字节的 code 代替路由字符串
+type dictionary struct {
+	sync.RWMutex
+	routes map[string]uint16 // 路由信息映射为uint16
+	codes  map[uint16]string // uint16映射为路由信息
+	next   uint16            // 下一个自动分配的 code
+}
+
+var dict = &dictionary{
+	routes: make(map[string]uint16),
+	codes:  make(map[uint16]string),
+	next:   1,
+}
+
+// SetDictionary 设置固定 code 的路由（比如配置文件中的 dict），需要在 AddRoutes 之前调用
+func SetDictionary(d map[string]uint16) {
+	if d == nil {
+		return
+	}
+	dict.Lock()
+	defer dict.Unlock()
+	for route, code := range d {
+		r := strings.TrimSpace(route) //去掉开头结尾的空格
+		// duplication check
+		if _, ok := dict.routes[r]; ok {
+			logs.Error("duplicated route(route: %s, code: %d)", r, code)
+			continue
+		}
+		if _, ok := dict.codes[code]; ok {
+			logs.Error("duplicated route(route: %s, code: %d)", r, code)
+			continue
+		}
+		dict.routes[r] = code
+		dict.codes[code] = r
+		if code >= dict.next {
+			dict.next = code + 1
+		}
+	}
+}
+
+// AddRoutes 为还没有 code 的路由分配 code，按路由排序后分配，相同的路由列表在每个 connector 上得到相同的字典
+func AddRoutes(routes ...string) {
+	sorted := make([]string, 0, len(routes))
+	for _, route := range routes {
+		sorted = append(sorted, strings.TrimSpace(route))
+	}
+	sort.Strings(sorted)
+	dict.Lock()
+	defer dict.Unlock()
+	for _, r := range sorted {
+		if r == "" {
+			continue
+		}
+		if _, ok := dict.routes[r]; ok {
+			continue
+		}
+		for {
+			if _, ok := dict.codes[dict.next]; !ok {
+				break
+			}
+			dict.next++
+		}
+		if dict.next == 0 {
+			// code 用完了（回绕），剩下的路由不压缩
+			logs.Error("route dictionary is full, route %s not compressed", r)
+			return
+		}
+		dict.routes[r] = dict.next
+		dict.codes[dict.next] = r
+		dict.next++
+	}
+}
+
+// GetDictionary 返回字典的拷贝，用于握手下发给客户端
+func GetDictionary() map[string]uint16 {
+	dict.RLock()
+	defer dict.RUnlock()
+	res := make(map[string]uint16, len(dict.routes))
+	for route, code := range dict.routes {
+		res[route] = code
+	}
+	return res
+}
+
+func GetRoute(code uint16) (route string, found bool) {
+	dict.RLock()
+	defer dict.RUnlock()
+	route, found = dict.codes[code]
+	return route, found
+}
+
+func getCode(route string) (code uint16, found bool) {
+	dict.RLock()
+	defer dict.RUnlock()
+	code, found = dict.routes[route]
+	return code, found
+}
@@ -0,0 +1,32 @@
+package protocol
+
+import "testing"
+
+func TestDictionaryCompressRoute(t *testing.T) {
+	SetDictionary(map[string]uint16{"connector.entryHandler.entry": 5})
+	AddRoutes("ServerMessagePush", "game.unionHandler.joinRoom", "connector.entryHandler.entry")
+	d := GetDictionary()
+	if d["connector.entryHandler.entry"] != 5 {
+		t.Fatalf("fixed code changed: %v", d)
+	}
+	code, ok := d["ServerMessagePush"]
+	if !ok || code <= 5 {
+		t.Fatalf("unexpected code for push route: %v", d)
+	}
+
+	body, err := MessageEncode(&Message{Type: Push, Route: "ServerMessagePush", Data: []byte(`{}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// flag + 2 字节 code + data
+	if body[0]&RouteCompressMask == 0 || len(body) != 3+len(`{}`) {
+		t.Fatalf("route not compressed: %v", body)
+	}
+	m, err := MessageDecode(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Route != "ServerMessagePush" || string(m.Data) != `{}` {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+}
@@ -0,0 +1,44 @@
+package protocol
+
+import "testing"
+
+// 任意输入都不能 panic，种子在 testdata/fuzz 中：go test -fuzz=FuzzDecode ./framework/protocol
+func FuzzDecode(f *testing.F) {
+	f.Add(encodeRequest(f, 1, "connector.entryHandler.entry", `{"token":"x"}`))
+	f.Add([]byte{byte(Handshake), 0, 0, 2, '{', '}'})
+	f.Add([]byte{byte(Heartbeat), 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, err := Decode(data)
+		if err == nil && int(p.Len)+HeaderLen != len(data) {
+			t.Fatalf("packet len %d does not match input %d", p.Len, len(data))
+		}
+		packets, err := DecodeAll(data)
+		if err == nil && len(data) > 0 && len(packets) == 0 {
+			t.Fatal("no packet decoded without error")
+		}
+	})
+}
+
+func FuzzMessageDecode(f *testing.F) {
+	body, _ := MessageEncode(&Message{Type: Request, ID: 300, Route: "game.unionHandler.joinRoom", Data: []byte(`{"roomID":"1"}`)})
+	f.Add(body)
+	body, _ = MessageEncode(&Message{Type: Push, Route: "ServerMessagePush", Data: []byte(`{}`)})
+	f.Add(body)
+	f.Add([]byte{byte(Request) << 1, 0xFF, 0xFF})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := MessageDecode(data)
+		if err != nil {
+			return
+		}
+		// 能解码的消息重新编码后仍然可以解码
+		if m.Type == Request || m.Type == Response || m.Type == Notify || m.Type == Push {
+			encoded, err := MessageEncode(&m)
+			if err != nil {
+				return
+			}
+			if _, err := MessageDecode(encoded); err != nil {
+				t.Fatalf("re-encoded message decode err: %v", err)
+			}
+		}
+	})
+}
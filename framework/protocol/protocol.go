@@ -2,18 +2,11 @@ package protocol
 
 import (
 	"bytes"
-	"common/logs"
 	"compress/zlib"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"io"
-	"strings"
-)
-
-var (
-	routes = make(map[string]uint16) // 路由信息映射为uint16
-	codes  = make(map[uint16]string) // uint16映射为路由信息
 )
 
 type PackageType byte
@@ -37,6 +30,9 @@ const (
 	Push     MessageType = 0x03 // ----011- 推送
 )
 
+// ServerMessagePush 游戏推送给客户端的路由，data 中的 pushRouter 区分具体的推送
+const ServerMessagePush = "ServerMessagePush"
+
 // 掩码定义用来操作flag(1byte)
 const (
 	RouteCompressMask = 0x01 // 启用路由压缩 00000001
@@ -57,73 +53,106 @@ type Packet struct {
 	Body any
 }
 
-// 解码 Packet，并且如果 packet type 是 Handshake 会解码 body 为 HandshakeBody， 如果是 Data 会解码 body 为 Message
+// Decode 解码一个完整的 Packet，payload 的长度必须和包头中的长度一致，一帧中有多个 packet 时使用 DecodeAll 或 Decoder。
+// 如果 packet type 是 Handshake 会解码 body 为 HandshakeBody， 如果是 Data 会解码 body 为 Message
 func Decode(payload []byte) (*Packet, error) {
+	t, n, err := decodeHeader(payload, MaxPacketSize-1)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < HeaderLen+n {
+		return nil, ErrPacketTruncated
+	}
+	if len(payload) > HeaderLen+n {
+		return nil, ErrPacketTrailing
+	}
+	return decodeBody(t, payload[HeaderLen:])
+}
+
+// 解析包头，返回包类型和 body 长度
+func decodeHeader(payload []byte, maxSize int) (PackageType, int, error) {
 	if len(payload) < HeaderLen {
-		return nil, errors.New("data len invalid")
+		return None, 0, ErrPacketTruncated
 	}
-	p := &Packet{}
 	// 第一个字节是包类型
-	p.Type = PackageType(payload[0])
+	t := PackageType(payload[0])
+	if t < Handshake || t > Kick {
+		return None, 0, ErrPacketType
+	}
 	// 1 到 3 是包长度，剩下的都是 body 部分
-	p.Len = uint32(BytesToInt(payload[1:HeaderLen]))
+	n := BytesToInt(payload[1:HeaderLen])
+	if n > maxSize {
+		return None, 0, ErrPacketTooLarge
+	}
+	return t, n, nil
+}
+
+func decodeBody(t PackageType, body []byte) (*Packet, error) {
+	p := &Packet{
+		Type: t,
+		Len:  uint32(len(body)),
+	}
 	if p.Type == Handshake {
-		var body HandshakeBody
+		var hb HandshakeBody
 		// 握手包，body 是 HandshakeBody 类型，专门进行解码
-		err := json.Unmarshal(payload[HeaderLen:], &body)
-		if err != nil {
-			return nil, err
-		}
-		if body.Sys.Dict != nil {
-			SetDictionary(body.Sys.Dict)
+		// 路由字典由服务端生成，不使用客户端握手带上来的 dict
+		p.Body = hb
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &hb); err != nil {
+				return p, err
+			}
+			p.Body = hb
 		}
-		p.Body = body
 	}
 	if p.Type == Data {
 		// 数据包，body 是官方所说的 message 类型，专门进行解码
-		m, err := MessageDecode(payload[HeaderLen:])
+		m, err := MessageDecode(body)
 		if err != nil {
-			return nil, err
+			// 返回已解析出的部分（比如 request 的 ID），方便给客户端回错误响应
+			p.Body = m
+			return p, err
 		}
 		p.Body = m
 	}
 	return p, nil
 }
 
-func SetDictionary(dict map[string]uint16) {
-	if dict == nil {
-		return
-	}
-
-	for route, code := range dict {
-		r := strings.TrimSpace(route) //去掉开头结尾的空格
-		// duplication check
-		if _, ok := routes[r]; ok {
-			logs.Error("duplicated route(route: %s, code: %d)", r, code)
-			return
-		}
-
-		if _, ok := codes[code]; ok {
-			logs.Error("duplicated route(route: %s, code: %d)", r, code)
-			return
-		}
+func MessageEncode(m *Message) ([]byte, error) {
+	return messageEncode(m, m.Data, false)
+}
 
-		// update map, using last value when key duplicated
-		routes[r] = code
-		codes[code] = r
+// MessageEncodeCompressed Data 超过 threshold 字节时用 zlib 压缩并设置 GZIPMask，客户端按标识解压；
+// threshold <= 0 或者压缩后没有变小时按原样编码
+func MessageEncodeCompressed(m *Message, threshold int) ([]byte, error) {
+	if threshold <= 0 || len(m.Data) <= threshold {
+		return MessageEncode(m)
+	}
+	data, err := DeflateData(m.Data)
+	if err != nil {
+		return nil, err
 	}
+	if len(data) >= len(m.Data) {
+		return MessageEncode(m)
+	}
+	return messageEncode(m, data, true)
 }
 
-func MessageEncode(m *Message) ([]byte, error) {
+func messageEncode(m *Message, data []byte, gzip bool) ([]byte, error) {
 	if m.Type < Request || m.Type > Push {
 		return nil, errors.New("invalid message type")
 	}
 	buf := make([]byte, 0)
 	flag := byte(m.Type) << 1
-	code, compressed := routes[m.Route]
+	code, compressed := getCode(m.Route)
 	if compressed {
 		flag |= RouteCompressMask
 	}
+	if m.Error {
+		flag |= ErrorMask
+	}
+	if gzip {
+		flag |= GZIPMask
+	}
 	buf = append(buf, flag)
 	if m.Type == Request || m.Type == Response {
 		n := m.ID
@@ -149,7 +178,7 @@ func MessageEncode(m *Message) ([]byte, error) {
 		}
 	}
 
-	buf = append(buf, m.Data...)
+	buf = append(buf, data...)
 	return buf, nil
 }
 
@@ -178,6 +207,9 @@ func routable(t MessageType) bool {
 // ------------------------------------------
 func MessageDecode(body []byte) (Message, error) {
 	m := Message{}
+	if len(body) == 0 {
+		return m, errors.New("invalid message")
+	}
 	// 第一个字节是 flag
 	flag := body[0]
 	m.Type = MessageType((flag >> 1) & TypeMask)
@@ -188,26 +220,31 @@ func MessageDecode(body []byte) (Message, error) {
 	dataLen := len(body)
 	if m.Type == Request || m.Type == Response {
 		id := uint(0)
+		end := false
 		// little end byte order
-		// variant length encode
-		for i := offset; i < dataLen; i++ {
+		// variant length encode，最多 5 个字节
+		for i := offset; i < dataLen && i < offset+5; i++ {
 			b := body[i]
 			id += uint(b&0x7F) << uint(7*(i-offset))
 			if b < 128 {
 				offset = i + 1
+				end = true
 				break
 			}
 		}
+		if !end {
+			return m, errors.New("invalid message id")
+		}
 		m.ID = id
 	}
-	if offset > dataLen {
-		return m, errors.New("invalid message")
-	}
 	m.Error = flag&ErrorMask == ErrorMask
 	if m.Type == Request || m.Type == Notify || m.Type == Push {
 		//route 解析
 		if flag&RouteCompressMask == 1 {
 			m.routeCompressed = true
+			if offset+2 > dataLen {
+				return m, errors.New("invalid message route")
+			}
 			code := binary.BigEndian.Uint16(body[offset:(offset + 2)])
 			route, found := GetRoute(code)
 			if !found {
@@ -218,15 +255,18 @@ func MessageDecode(body []byte) (Message, error) {
 
 		} else {
 			m.routeCompressed = false
-			rl := body[offset]
+			if offset >= dataLen {
+				return m, errors.New("invalid message route")
+			}
+			rl := int(body[offset])
 			offset++
-			m.Route = string(body[offset:(offset + int(rl))])
-			offset += int(rl)
+			if offset+rl > dataLen {
+				return m, errors.New("invalid message route")
+			}
+			m.Route = string(body[offset:(offset + rl)])
+			offset += rl
 		}
 	}
-	if offset > dataLen {
-		return m, errors.New("invalid message")
-	}
 	m.Data = body[offset:]
 	var err error
 	if flag&GZIPMask == GZIPMask {
@@ -238,26 +278,42 @@ func MessageDecode(body []byte) (Message, error) {
 	return m, nil
 }
 
-func GetRoute(code uint16) (route string, found bool) {
-	route, found = codes[code]
-	return route, found
-}
-
+// InflateData 解压后超过 MaxPacketSize 的数据视为非法，防止很小的包解压出巨大的数据
 func InflateData(data []byte) ([]byte, error) {
 	zr, err := zlib.NewReader(bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
 	defer zr.Close()
-	return io.ReadAll(zr)
+	res, err := io.ReadAll(io.LimitReader(zr, MaxPacketSize))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) >= MaxPacketSize {
+		return nil, ErrPacketTooLarge
+	}
+	return res, nil
+}
+
+func DeflateData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func Encode(packageType PackageType, body []byte) ([]byte, error) {
 	if packageType == None {
 		return nil, errors.New("encode unsupported packageType")
 	}
-	if len(body) > MaxPacketSize {
-		return nil, errors.New("encode body size too big")
+	// 包头只有 3 个字节表示长度
+	if len(body) >= MaxPacketSize {
+		return nil, ErrPacketTooLarge
 	}
 	buf := make([]byte, len(body)+HeaderLen)
 	//1. 类型
@@ -296,6 +352,7 @@ type Sys struct {
 	Heartbeat    uint8             `json:"heartbeat"`
 	Dict         map[string]uint16 `json:"dict"`
 	Serializer   string            `json:"serializer"`
+	Compress     bool              `json:"compress"` // 客户端握手时声明支持解压，服务端响应是否启用压缩
 }
 
 type HandshakeResponse struct {
@@ -0,0 +1,35 @@
+package protocol
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMessageEncodeCompressed(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"cardList":[1,2,3,4,5,6,7,8,9]},`), 64)
+	m := &Message{Type: Push, Route: "GameMessagePush", Data: data}
+
+	body, err := MessageEncodeCompressed(m, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body[0]&GZIPMask == 0 || len(body) >= len(data) {
+		t.Fatalf("data not compressed, len=%d", len(body))
+	}
+	decoded, err := MessageDecode(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Route != m.Route || !bytes.Equal(decoded.Data, data) {
+		t.Fatalf("unexpected message after inflate: %s", decoded.Route)
+	}
+
+	// 没超过阈值按原样编码
+	body, err = MessageEncodeCompressed(m, len(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body[0]&GZIPMask != 0 {
+		t.Fatal("data below threshold should not be compressed")
+	}
+}
@@ -1,7 +1,21 @@
 package remote
 
+import "context"
+
 type Client interface {
 	Run() error
 	SendMsg(dst string, data []byte) error
+	// Request 发送消息到 dst 并等待响应，ctx 控制超时
+	Request(ctx context.Context, dst string, data []byte) ([]byte, error)
+	// HandleRequest 设置处理 Request 的 handler，需要在 Run 之前调用
+	HandleRequest(handler RequestHandler)
 	Close() error
 }
+
+// ClientFactory 创建订阅 serverId 的 Client，收到的消息写入 readChan
+type ClientFactory func(serverId string, readChan chan []byte) Client
+
+// NatsClientFactory 默认使用 NATS
+func NatsClientFactory(serverId string, readChan chan []byte) Client {
+	return NewNatsClient(serverId, readChan)
+}
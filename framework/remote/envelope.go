@@ -0,0 +1,219 @@
+package remote
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"framework/game"
+	"framework/protocol"
+)
+
+// Msg 在服务器之间传输的格式（envelope），第一个字节是版本号：
+//
+//	'{'  旧版本 json，只用于解码，兼容还没升级的节点
+//	0x01 二进制 v1
+//
+// 二进制 v1 依次为：version | type | cid | src | dst | router | uid | pushUser | body | sessionData | payloads，
+// 整数用 uvarint，字符串/字节为 uvarint 长度 + 内容；body 为 flag(有无 body) | type | id | route | error | data，
+// Data 直接以字节写入，不再像 json 那样 base64；sessionData 的值类型不固定，仍然用 json 编码；
+// payloads 只有推送才有，为个数 + 依次的序列化方式名和内容，没有时不写，旧版本的节点解码时忽略
+//
+// 滚动升级时先把所有节点的 nats.envelope 配置为 json，全部升级完成后再改为 binary（默认）
+const (
+	EnvelopeJSON   = "json"
+	EnvelopeBinary = "binary"
+
+	envelopeV1 byte = 0x01
+)
+
+var errEnvelope = errors.New("invalid remote message envelope")
+
+// EncodeMsg 按配置的 envelope 编码
+func EncodeMsg(msg *Msg) ([]byte, error) {
+	if game.Conf != nil && game.Conf.ServersConf.Nats.Envelope == EnvelopeJSON {
+		return json.Marshal(msg)
+	}
+	return encodeV1(msg)
+}
+
+// DecodeMsg 根据第一个字节识别 envelope 版本
+func DecodeMsg(data []byte, msg *Msg) error {
+	if len(data) == 0 {
+		return errEnvelope
+	}
+	switch data[0] {
+	case '{':
+		return json.Unmarshal(data, msg)
+	case envelopeV1:
+		return decodeV1(data[1:], msg)
+	default:
+		return errEnvelope
+	}
+}
+
+func encodeV1(msg *Msg) ([]byte, error) {
+	var sessionData []byte
+	if msg.SessionData != nil {
+		var err error
+		if sessionData, err = json.Marshal(msg.SessionData); err != nil {
+			return nil, err
+		}
+	}
+	size := 16 + len(msg.Cid) + len(msg.Src) + len(msg.Dst) + len(msg.Router) + len(msg.Uid) + len(sessionData)
+	for _, uid := range msg.PushUser {
+		size += len(uid) + 1
+	}
+	if msg.Body != nil {
+		size += len(msg.Body.Route) + len(msg.Body.Data) + 16
+	}
+	for name, payload := range msg.Payloads {
+		size += len(name) + len(payload) + 8
+	}
+	buf := make([]byte, 0, size)
+	buf = append(buf, envelopeV1)
+	buf = binary.AppendUvarint(buf, uint64(msg.Type))
+	buf = appendString(buf, msg.Cid)
+	buf = appendString(buf, msg.Src)
+	buf = appendString(buf, msg.Dst)
+	buf = appendString(buf, msg.Router)
+	buf = appendString(buf, msg.Uid)
+	buf = binary.AppendUvarint(buf, uint64(len(msg.PushUser)))
+	for _, uid := range msg.PushUser {
+		buf = appendString(buf, uid)
+	}
+	if msg.Body == nil {
+		buf = append(buf, 0)
+	} else {
+		buf = append(buf, 1, byte(msg.Body.Type))
+		buf = binary.AppendUvarint(buf, uint64(msg.Body.ID))
+		buf = appendString(buf, msg.Body.Route)
+		buf = appendBool(buf, msg.Body.Error)
+		buf = appendBytes(buf, msg.Body.Data)
+	}
+	buf = appendBytes(buf, sessionData)
+	if len(msg.Payloads) > 0 {
+		buf = binary.AppendUvarint(buf, uint64(len(msg.Payloads)))
+		for name, payload := range msg.Payloads {
+			buf = appendString(buf, name)
+			buf = appendBytes(buf, payload)
+		}
+	}
+	return buf, nil
+}
+
+func decodeV1(data []byte, msg *Msg) error {
+	r := &envelopeReader{data: data}
+	msg.Type = int(r.uvarint())
+	msg.Cid = r.string()
+	msg.Src = r.string()
+	msg.Dst = r.string()
+	msg.Router = r.string()
+	msg.Uid = r.string()
+	if n := r.uvarint(); n > 0 {
+		if n > uint64(len(r.data)) {
+			return errEnvelope
+		}
+		msg.PushUser = make([]string, n)
+		for i := range msg.PushUser {
+			msg.PushUser[i] = r.string()
+		}
+	}
+	if r.byte() == 1 {
+		msg.Body = &protocol.Message{
+			Type: protocol.MessageType(r.byte()),
+			ID:   uint(r.uvarint()),
+		}
+		msg.Body.Route = r.string()
+		msg.Body.Error = r.byte() == 1
+		msg.Body.Data = r.bytes()
+	}
+	sessionData := r.bytes()
+	if len(r.data) > 0 {
+		n := r.uvarint()
+		if n > uint64(len(r.data)) {
+			return errEnvelope
+		}
+		msg.Payloads = make(map[string][]byte, n)
+		for i := uint64(0); i < n; i++ {
+			name := r.string()
+			msg.Payloads[name] = r.bytes()
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if len(sessionData) > 0 {
+		return json.Unmarshal(sessionData, &msg.SessionData)
+	}
+	return nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+func appendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// 按顺序读取 v1 字段，出错后后续读取都返回零值，最后统一检查 err
+type envelopeReader struct {
+	data []byte
+	err  error
+}
+
+func (r *envelopeReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errEnvelope
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *envelopeReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) == 0 {
+		r.err = errEnvelope
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *envelopeReader) bytes() []byte {
+	n := r.uvarint()
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)) {
+		r.err = errEnvelope
+		return nil
+	}
+	if n == 0 {
+		return nil
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *envelopeReader) string() string {
+	return string(r.bytes())
+}
@@ -0,0 +1,121 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"framework/protocol"
+	"reflect"
+	"testing"
+)
+
+func testMsg() *Msg {
+	return &Msg{
+		Cid:    "6c1f2b0e-8d8a-4a55-b0b5-6f3a1c2d9e10-connector001-10001",
+		Src:    "connector001",
+		Dst:    "game-001",
+		Router: "gameHandler.gameMessageNotify",
+		Uid:    "1000123",
+		Body: &protocol.Message{
+			Type:  protocol.Request,
+			ID:    42,
+			Route: "game.gameHandler.gameMessageNotify",
+			Data:  bytes.Repeat([]byte(`{"type":302,"data":{"card":11}}`), 8),
+		},
+		SessionData: map[string]any{"roomId": "583920"},
+		PushUser:    []string{"1000123", "1000124", "1000125"},
+	}
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	msg := testMsg()
+	data, err := encodeV1(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Msg
+	if err := DecodeMsg(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, &decoded) {
+		t.Fatalf("round trip mismatch:\n%+v\n%+v", msg, &decoded)
+	}
+	// 截断的数据不能解码成功
+	for i := 1; i < len(data); i++ {
+		var m Msg
+		if err := DecodeMsg(data[:i], &m); err == nil {
+			t.Fatalf("truncated envelope at %d decoded without error", i)
+		}
+	}
+}
+
+// 推送带着按序列化方式预先编码的消息体，没有 payloads 的消息和旧版本的格式一致
+func TestEnvelopePayloads(t *testing.T) {
+	msg := testMsg()
+	plain, _ := encodeV1(msg)
+	msg.Payloads = map[string][]byte{"msgpack": {0x81, 0xa1, 0x61, 0x01}, "protobuf": {0x0a, 0x00}}
+	data, err := encodeV1(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, plain) {
+		t.Fatal("payloads should be appended after the v1 fields")
+	}
+	var decoded Msg
+	if err := DecodeMsg(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, &decoded) {
+		t.Fatalf("round trip mismatch:\n%+v\n%+v", msg, &decoded)
+	}
+}
+
+// 未升级的节点发来的 json 仍然可以解码
+func TestEnvelopeJSONCompatible(t *testing.T) {
+	msg := testMsg()
+	data, _ := json.Marshal(msg)
+	var decoded Msg
+	if err := DecodeMsg(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Cid != msg.Cid || !bytes.Equal(decoded.Body.Data, msg.Body.Data) {
+		t.Fatalf("unexpected msg: %+v", decoded)
+	}
+}
+
+func BenchmarkEnvelopeEncodeJSON(b *testing.B) {
+	msg := testMsg()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, _ := json.Marshal(msg)
+		b.SetBytes(int64(len(data)))
+	}
+}
+
+func BenchmarkEnvelopeEncodeBinary(b *testing.B) {
+	msg := testMsg()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, _ := encodeV1(msg)
+		b.SetBytes(int64(len(data)))
+	}
+}
+
+func BenchmarkEnvelopeDecodeJSON(b *testing.B) {
+	data, _ := json.Marshal(testMsg())
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var msg Msg
+		_ = json.Unmarshal(data, &msg)
+	}
+}
+
+func BenchmarkEnvelopeDecodeBinary(b *testing.B) {
+	data, _ := encodeV1(testMsg())
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		var msg Msg
+		_ = DecodeMsg(data, &msg)
+	}
+}
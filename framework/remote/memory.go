@@ -0,0 +1,127 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MemoryBus 进程内的消息总线，按 serverId 投递，和 NATS 一样没有订阅者的消息直接丢弃
+// connector、hall、game 运行在同一个进程（单机部署、测试）时使用，不需要外部 NATS 服务
+type MemoryBus struct {
+	sync.RWMutex
+	subs     map[string]chan []byte    // serverId -> 订阅者的 readChan
+	handlers map[string]RequestHandler // serverId -> 处理同步调用的 handler
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		subs:     make(map[string]chan []byte),
+		handlers: make(map[string]RequestHandler),
+	}
+}
+
+// Factory 创建连接到这条总线的 Client
+func (b *MemoryBus) Factory() ClientFactory {
+	return func(serverId string, readChan chan []byte) Client {
+		return NewMemoryClient(b, serverId, readChan)
+	}
+}
+
+func (b *MemoryBus) subscribe(serverId string, readChan chan []byte, handler RequestHandler) {
+	b.Lock()
+	defer b.Unlock()
+	b.subs[serverId] = readChan
+	if handler != nil {
+		b.handlers[serverId] = handler
+	}
+}
+
+func (b *MemoryBus) unsubscribe(serverId string) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.subs, serverId)
+	delete(b.handlers, serverId)
+}
+
+func (b *MemoryBus) publish(dst string, data []byte) {
+	b.RLock()
+	readChan, ok := b.subs[dst]
+	b.RUnlock()
+	if !ok {
+		return
+	}
+	// 复制一份，避免发送方复用 data
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	readChan <- buf
+}
+
+func (b *MemoryBus) request(ctx context.Context, dst string, data []byte) ([]byte, error) {
+	b.RLock()
+	handler, ok := b.handlers[dst]
+	b.RUnlock()
+	if !ok {
+		return nil, errors.New("no responders available for request")
+	}
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	reply := make(chan []byte, 1)
+	go func() {
+		reply <- handler(buf)
+	}()
+	select {
+	case data := <-reply:
+		return data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type MemoryClient struct {
+	serverId       string
+	bus            *MemoryBus
+	readChan       chan []byte
+	requestHandler RequestHandler
+	running        bool
+}
+
+func NewMemoryClient(bus *MemoryBus, serverId string, readChan chan []byte) *MemoryClient {
+	return &MemoryClient{
+		serverId: serverId,
+		bus:      bus,
+		readChan: readChan,
+	}
+}
+
+func (m *MemoryClient) Run() error {
+	// 在 serverId 上订阅
+	m.bus.subscribe(m.serverId, m.readChan, m.requestHandler)
+	m.running = true
+	return nil
+}
+
+func (m *MemoryClient) SendMsg(dst string, data []byte) error {
+	if !m.running {
+		return errors.New("memory client not running")
+	}
+	m.bus.publish(dst, data)
+	return nil
+}
+
+func (m *MemoryClient) Request(ctx context.Context, dst string, data []byte) ([]byte, error) {
+	if !m.running {
+		return nil, errors.New("memory client not running")
+	}
+	return m.bus.request(ctx, dst, data)
+}
+
+func (m *MemoryClient) HandleRequest(handler RequestHandler) {
+	m.requestHandler = handler
+}
+
+func (m *MemoryClient) Close() error {
+	m.bus.unsubscribe(m.serverId)
+	m.running = false
+	return nil
+}
@@ -10,10 +10,12 @@ type Msg struct {
 	Router      string
 	Uid         string
 	SessionData map[string]any
-	Type        int // 0 normal 1 session
+	Type        int // 0 normal 1 session 2 kick
 	PushUser    []string
+	Payloads    map[string][]byte // 推送按客户端序列化方式预先编码的消息体（json 以外），见 serializer.MarshalPush
 }
 
-// 0 normal 推送至客户端；1 session 更新本地 session 相关数据，不推送
+// 0 normal 推送至客户端；1 session 更新本地 session 相关数据，不推送；2 kick 踢掉本地 Uid 的连接（在其他 connector 重复登录）
 const NormalType = 0
 const SessionType = 1
+const KickType = 2
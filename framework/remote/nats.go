@@ -2,6 +2,7 @@ package remote
 
 import (
 	"common/logs"
+	"context"
 	"errors"
 	"framework/game"
 
@@ -9,9 +10,10 @@ import (
 )
 
 type NatsClient struct {
-	serverId string
-	conn     *nats.Conn
-	readChan chan []byte
+	serverId       string
+	conn           *nats.Conn
+	readChan       chan []byte
+	requestHandler RequestHandler
 }
 
 func NewNatsClient(serverId string, readChan chan []byte) *NatsClient {
@@ -39,6 +41,22 @@ func (n *NatsClient) SendMsg(dst string, data []byte) error {
 	return n.conn.Publish(dst, data)
 }
 
+// Request 基于 NATS request/reply，对方在 serverId.rpc 上订阅并响应
+func (n *NatsClient) Request(ctx context.Context, dst string, data []byte) ([]byte, error) {
+	if n.conn == nil {
+		return nil, errors.New("conn is nil")
+	}
+	msg, err := n.conn.RequestWithContext(ctx, rpcSubject(dst), data)
+	if err != nil {
+		return nil, err
+	}
+	return msg.Data, nil
+}
+
+func (n *NatsClient) HandleRequest(handler RequestHandler) {
+	n.requestHandler = handler
+}
+
 func (n *NatsClient) Close() error {
 	if n.conn != nil {
 		n.conn.Close()
@@ -55,4 +73,18 @@ func (n *NatsClient) sub() {
 	if err != nil {
 		logs.Error("nat sub err: %v", err)
 	}
+	if n.requestHandler == nil {
+		return
+	}
+	// 同步调用在 serverId.rpc 上订阅，每个请求单独一个协程处理，避免慢请求阻塞订阅
+	_, err = n.conn.Subscribe(rpcSubject(n.serverId), func(msg *nats.Msg) {
+		go func() {
+			if err := msg.Respond(n.requestHandler(msg.Data)); err != nil {
+				logs.Error("nat respond err: %v", err)
+			}
+		}()
+	})
+	if err != nil {
+		logs.Error("nat rpc sub err: %v", err)
+	}
 }
@@ -0,0 +1,122 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"framework/balancer"
+	"framework/game"
+	"framework/msError"
+	"framework/protocol"
+	"time"
+)
+
+// RequestHandler 处理其他节点通过 Client.Request 发来的同步调用，返回值作为响应
+type RequestHandler func(data []byte) []byte
+
+// 同步调用按调用方的 uid 一致性哈希选择目标节点，同一个用户的调用落在同一个节点上
+var rpcBalancer balancer.Balancer = balancer.NewHashBalancer()
+
+// DefaultRPCTimeout 目标节点没有配置 RPCTimeOut、ctx 也没有 deadline 时的调用超时，避免调用方一直占着执行槽位
+const DefaultRPCTimeout = 5 * time.Second
+
+// 同步调用的 NATS subject，和普通消息的 serverId subject 分开
+func rpcSubject(serverId string) string {
+	return serverId + ".rpc"
+}
+
+// Call 同步调用 serverType 类型节点上的 route（形如 userHandler.getUnionPermission），等待响应解码到 resp
+// from 为发起调用的消息，调用方的 uid、session 数据会带给被调用的节点；ctx 没有 deadline 时使用目标节点的 RPCTimeOut，未配置时使用 DefaultRPCTimeout
+// 被调用的 handler 返回 *msError.Error 时，Call 返回该错误
+func Call(ctx context.Context, clt Client, from *Msg, serverType, route string, req any, resp any) error {
+	// 不能调用自己：调用带着同样的 uid、session 数据，会排在正在等待的调用方后面，直到超时
+	var servers []*game.ServersConfig
+	for _, s := range game.Conf.ServersConf.TypeServer[serverType] {
+		if s.ID != from.Dst {
+			servers = append(servers, s)
+		}
+	}
+	serverId, err := rpcBalancer.Select(msgSession{from}, serverType, servers)
+	if err != nil {
+		return err
+	}
+	var server *game.ServersConfig
+	for _, s := range servers {
+		if s.ID == serverId {
+			server = s
+		}
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := DefaultRPCTimeout
+		if server.RPCTimeOut > 0 {
+			timeout = time.Duration(server.RPCTimeOut) * time.Second
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	msg := Msg{
+		Cid:         from.Cid,
+		Uid:         from.Uid,
+		Src:         from.Dst,
+		Dst:         server.ID,
+		Router:      route,
+		SessionData: from.SessionData,
+		Body: &protocol.Message{
+			Type:  protocol.Request,
+			Route: route,
+			Data:  data,
+		},
+	}
+	msgBytes, err := EncodeMsg(&msg)
+	if err != nil {
+		return err
+	}
+	replyBytes, err := clt.Request(ctx, server.ID, msgBytes)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return msError.RequestTimeout
+		}
+		return err
+	}
+	var reply Msg
+	if err := DecodeMsg(replyBytes, &reply); err != nil {
+		return err
+	}
+	if reply.Body == nil {
+		return msError.RouteUnsupported
+	}
+	if reply.Body.Error {
+		var msErr msError.Error
+		if err := json.Unmarshal(reply.Body.Data, &msErr); err != nil {
+			return err
+		}
+		return &msErr
+	}
+	if resp == nil || len(reply.Body.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(reply.Body.Data, resp)
+}
+
+// msgSession 用调用方的消息做负载均衡，不做粘性绑定
+type msgSession struct {
+	msg *Msg
+}
+
+func (s msgSession) HashKey() string {
+	if s.msg.Uid != "" {
+		return s.msg.Uid
+	}
+	return s.msg.Cid
+}
+
+func (s msgSession) GetServer(serverType string) (string, bool) {
+	return "", false
+}
+
+func (s msgSession) BindServer(serverType, serverId string) {}
@@ -2,8 +2,10 @@ package remote
 
 import (
 	"common/logs"
-	"encoding/json"
+	"context"
+	"framework/directory"
 	"framework/protocol"
+	"framework/serializer"
 	"sync"
 )
 
@@ -14,11 +16,13 @@ type Session struct {
 	pushChan        chan *UserPushMsg
 	data            map[string]any
 	pushSessionChan chan map[string]any
+	presence        directory.Presence // 查询用户所在的 connector，为空时都推送到当前请求的 connector
 }
 
 type PushMsg struct {
-	data   []byte
-	router string
+	data     []byte
+	payloads map[string][]byte
+	router   string
 }
 
 type UserPushMsg struct {
@@ -26,13 +30,14 @@ type UserPushMsg struct {
 	Users   []string `json:"users"`
 }
 
-func NewSession(client Client, msg *Msg) *Session {
+func NewSession(client Client, msg *Msg, presence directory.Presence) *Session {
 	session := Session{
 		clt:             client,
 		msg:             msg,
 		pushChan:        make(chan *UserPushMsg, 1024),
 		data:            make(map[string]any),
 		pushSessionChan: make(chan map[string]any, 1024),
+		presence:        presence,
 	}
 	go session.pushChanReader()
 	go session.pushSession()
@@ -43,12 +48,24 @@ func (s *Session) GetUid() string {
 	return s.msg.Uid
 }
 
+// Call 同步调用其他节点的 handler，比如 game 向 hall 查询用户的联盟权限
+func (s *Session) Call(ctx context.Context, serverType, route string, req any, resp any) error {
+	return Call(ctx, s.clt, s.msg, serverType, route, req, resp)
+}
+
+// Push 推送给 users，data 在这里按每种序列化方式编码一次（见 serializer.MarshalPush），connector 按连接选用。
+// 游戏的推送是 game/api 中定义的 proto 消息，protobuf 连接才能收到 protobuf 编码
 func (s *Session) Push(users []string, data any, router string) {
-	msg, _ := json.Marshal(data)
+	msg, payloads, err := serializer.MarshalPush(data)
+	if err != nil {
+		logs.Error("push message marshal err: %v, router=%s", err, router)
+		return
+	}
 	pushMsg := &UserPushMsg{
 		PushMsg: PushMsg{
-			data:   msg,
-			router: router,
+			data:     msg,
+			payloads: payloads,
+			router:   router,
 		},
 		Users: users,
 	}
@@ -65,23 +82,54 @@ func (s *Session) pushChanReader() {
 				Route: data.PushMsg.router,
 				Data:  data.PushMsg.data,
 			}
-			msg := Msg{
-				Dst:      s.msg.Src,
-				Src:      s.msg.Dst,
-				Body:     &pushMsg,
-				Cid:      s.msg.Cid,
-				Uid:      s.GetUid(),
-				PushUser: data.Users,
-			}
-			res, _ := json.Marshal(msg)
-			logs.Info("push message dst: %v", msg.Dst)
-			if err := s.clt.SendMsg(msg.Dst, res); err != nil {
-				logs.Error("push message err: %v, msg: %v", err, msg)
+			// 推送的用户可能连接在不同的 connector 上，每个 connector 发一条
+			for dst, users := range s.groupByConnector(data.Users) {
+				msg := Msg{
+					Dst:      dst,
+					Src:      s.msg.Dst,
+					Body:     &pushMsg,
+					Cid:      s.msg.Cid,
+					Uid:      s.GetUid(),
+					PushUser: users,
+					Payloads: data.PushMsg.payloads,
+				}
+				res, err := EncodeMsg(&msg)
+				if err != nil {
+					logs.Error("push message encode err: %v", err)
+					continue
+				}
+				logs.Info("push message dst: %v", msg.Dst)
+				if err := s.clt.SendMsg(msg.Dst, res); err != nil {
+					logs.Error("push message err: %v, msg: %v", err, msg)
+				}
 			}
 		}
 	}
 }
 
+// 按用户所在的 connector 分组，查不到的用户推送到当前请求的 connector
+func (s *Session) groupByConnector(users []string) map[string][]string {
+	groups := make(map[string][]string)
+	if s.presence == nil {
+		groups[s.msg.Src] = users
+		return groups
+	}
+	connectors, err := s.presence.Lookup(users)
+	if err != nil {
+		logs.Error("presence lookup err: %v", err)
+		groups[s.msg.Src] = users
+		return groups
+	}
+	for _, uid := range users {
+		dst, ok := connectors[uid]
+		if !ok {
+			dst = s.msg.Src
+		}
+		groups[dst] = append(groups[dst], uid)
+	}
+	return groups
+}
+
 func (s *Session) Put(key string, val any) {
 	s.Lock()
 	defer s.Unlock()
@@ -100,7 +148,11 @@ func (s *Session) pushSession() {
 				SessionData: session,
 				Type:        SessionType,
 			}
-			data, _ := json.Marshal(msg)
+			data, err := EncodeMsg(&msg)
+			if err != nil {
+				logs.Error("push session encode err: %v", err)
+				continue
+			}
 			if err := s.clt.SendMsg(msg.Dst, data); err != nil {
 				logs.Error("push session err: %v", err)
 			}
@@ -108,7 +160,7 @@ func (s *Session) pushSession() {
 	}
 }
 
-func (s *Session) setData(data map[string]any) {
+func (s *Session) SetData(data map[string]any) {
 	s.Lock()
 	defer s.Unlock()
 	for k, v := range data {
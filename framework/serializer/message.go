@@ -0,0 +1,85 @@
+package serializer
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// protoValue 把 proto 消息按 proto 定义转换为通用结构，json、msgpack 编码 proto 消息时使用，字段和原来 json 标签的结构体一致：
+//   - key 为字段的 json 名，所有字段都输出，没有设置的 message、optional 字段为 null，空的 repeated 为 []
+//   - 整数保持为整数（protojson 会把 int64 编码为字符串）
+//   - google.protobuf.Any 直接展开为其中的消息，外层消息的 data 不需要知道具体类型
+//   - 只有一个 repeated 字段 values 的消息（比如二维数组的一行）直接编码为数组
+func protoValue(m protoreflect.Message) (any, error) {
+	if !m.IsValid() {
+		return nil, nil
+	}
+	desc := m.Descriptor()
+	if desc.FullName() == "google.protobuf.Any" {
+		inner, err := anypb.UnmarshalNew(m.Interface().(*anypb.Any), proto.UnmarshalOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return protoValue(inner.ProtoReflect())
+	}
+	fields := desc.Fields()
+	if fields.Len() == 1 && fields.Get(0).IsList() && fields.Get(0).Name() == "values" {
+		return fieldValue(fields.Get(0), m.Get(fields.Get(0)))
+	}
+	obj := make(map[string]any, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.HasPresence() && !m.Has(fd) {
+			obj[fd.JSONName()] = nil
+			continue
+		}
+		v, err := fieldValue(fd, m.Get(fd))
+		if err != nil {
+			return nil, err
+		}
+		obj[fd.JSONName()] = v
+	}
+	return obj, nil
+}
+
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		items := make([]any, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			item, err := singularValue(fd, list.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case fd.IsMap():
+		obj := make(map[string]any, v.Map().Len())
+		var err error
+		v.Map().Range(func(k protoreflect.MapKey, val protoreflect.Value) bool {
+			var item any
+			if item, err = singularValue(fd.MapValue(), val); err != nil {
+				return false
+			}
+			obj[k.String()] = item
+			return true
+		})
+		return obj, err
+	default:
+		return singularValue(fd, v)
+	}
+}
+
+func singularValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoValue(v.Message())
+	case protoreflect.EnumKind:
+		return int32(v.Enum()), nil
+	default:
+		return v.Interface(), nil
+	}
+}
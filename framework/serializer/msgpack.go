@@ -0,0 +1,46 @@
+package serializer
+
+import (
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+// msgpack 结构体字段名沿用 json 标签，和 json 格式的字段一致
+type msgpackSerializer struct {
+	handle *codec.MsgpackHandle
+}
+
+func newMsgpackSerializer() *msgpackSerializer {
+	h := &codec.MsgpackHandle{}
+	h.WriteExt = true
+	h.RawToString = true
+	// 解码到 any 时使用 map[string]any，和 json 一致
+	h.MapType = reflect.TypeOf(map[string]any(nil))
+	return &msgpackSerializer{
+		handle: h,
+	}
+}
+
+func (s *msgpackSerializer) Name() string {
+	return Msgpack
+}
+
+// proto 消息和 json 一样按 proto 定义编码（见 protoValue）
+func (s *msgpackSerializer) Marshal(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		value, err := protoValue(m.ProtoReflect())
+		if err != nil {
+			return nil, err
+		}
+		v = value
+	}
+	var buf []byte
+	err := codec.NewEncoderBytes(&buf, s.handle).Encode(v)
+	return buf, err
+}
+
+func (s *msgpackSerializer) Unmarshal(data []byte, v any) error {
+	return codec.NewDecoderBytes(data, s.handle).Decode(v)
+}
@@ -0,0 +1,33 @@
+package serializer
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var errNotProtoMessage = errors.New("protobuf serializer: not a proto.Message")
+
+// protobuf 只编解码有 proto 定义的消息（proto.Message），比如 game/api 中定义的推送。
+// 没有定义的消息体（请求、handler 的响应）在 protobuf 连接上仍然是 json，和 pomelo-protobuf 按路由有没有定义选择编码一样
+type protobufSerializer struct{}
+
+func (s *protobufSerializer) Name() string {
+	return Protobuf
+}
+
+func (s *protobufSerializer) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, errNotProtoMessage
+	}
+	return proto.Marshal(m)
+}
+
+func (s *protobufSerializer) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return errNotProtoMessage
+	}
+	return proto.Unmarshal(data, m)
+}
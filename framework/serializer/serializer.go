@@ -0,0 +1,152 @@
+// 消息体序列化：客户端握手时在 Sys.Serializer 中选择 json、protobuf 或 msgpack。
+// 服务器之间（connector <-> 后端节点）的消息体是 json，connector 收发客户端消息时按连接选择的序列化方式转换；
+// 推送在发出的节点上用 MarshalPush 按每种序列化方式编码好，connector 直接选用
+package serializer
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	JSON     = "json"
+	Protobuf = "protobuf"
+	Msgpack  = "msgpack"
+)
+
+type Serializer interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var serializers = map[string]Serializer{
+	JSON:     &jsonSerializer{},
+	Protobuf: &protobufSerializer{},
+	Msgpack:  newMsgpackSerializer(),
+}
+
+// Get 按名字查找，名字为空时使用 json
+func Get(name string) (Serializer, bool) {
+	if name == "" {
+		name = JSON
+	}
+	s, ok := serializers[name]
+	return s, ok
+}
+
+// Default json
+func Default() Serializer {
+	return serializers[JSON]
+}
+
+// MarshalPush 推送在发出推送的节点上按每种序列化方式各编码一次，connector 按连接的序列化方式直接选用，不再转换。
+// data 是 json，服务器之间默认使用；payloads 是其他序列化方式的编码。
+// 一个推送路由上有多种消息，protobuf 编码为 google.protobuf.Any，客户端按 type_url 解出具体的消息；
+// v 不是 proto.Message 时没有 protobuf 编码，protobuf 连接收到的是 json
+func MarshalPush(v any) (data []byte, payloads map[string][]byte, err error) {
+	if data, err = Default().Marshal(v); err != nil {
+		return nil, nil, err
+	}
+	payloads = make(map[string][]byte, len(serializers)-1)
+	for name, s := range serializers {
+		body := v
+		switch name {
+		case JSON:
+			continue
+		case Protobuf:
+			m, ok := v.(proto.Message)
+			if !ok {
+				continue
+			}
+			if body, err = anypb.New(m); err != nil {
+				return nil, nil, err
+			}
+		}
+		if payloads[name], err = s.Marshal(body); err != nil {
+			return nil, nil, err
+		}
+	}
+	return data, payloads, nil
+}
+
+// FromJSON 把服务器之间的 json 消息体转换为 s 的格式发给客户端。
+// protobuf 只用于有 proto 定义的消息，没有定义的消息体原样使用 json
+func FromJSON(data []byte, s Serializer) ([]byte, error) {
+	if s.Name() == JSON || s.Name() == Protobuf || len(data) == 0 {
+		return data, nil
+	}
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Marshal(v)
+}
+
+// ToJSON 把客户端发来的 s 格式消息体转换为 json 转发给后端，protobuf 连接的请求体没有 proto 定义，原样是 json
+func ToJSON(data []byte, s Serializer) ([]byte, error) {
+	if s.Name() == JSON || s.Name() == Protobuf || len(data) == 0 {
+		return data, nil
+	}
+	var v any
+	if err := s.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// json 解码为通用结构，整数保持为 int64，否则 msgpack 会把所有数字都编码成浮点数
+func decodeJSON(data []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return normalizeNumber(v), nil
+}
+
+func normalizeNumber(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normalizeNumber(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeNumber(item)
+		}
+	}
+	return v
+}
+
+type jsonSerializer struct{}
+
+func (s *jsonSerializer) Name() string {
+	return JSON
+}
+
+// proto 消息按 proto 定义编码（见 protoValue），其他类型按 json 标签
+func (s *jsonSerializer) Marshal(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		value, err := protoValue(m.ProtoReflect())
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(value)
+	}
+	return json.Marshal(v)
+}
+
+func (s *jsonSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
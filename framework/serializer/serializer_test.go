@@ -0,0 +1,89 @@
+package serializer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type pushMsg struct {
+	Type       int    `json:"type"`
+	Data       any    `json:"data"`
+	PushRouter string `json:"pushRouter"`
+}
+
+func TestTranscode(t *testing.T) {
+	src, _ := json.Marshal(pushMsg{Type: 401, Data: map[string]any{"handCards": [][]int{{1, 2}, {3}}, "tick": 1.5}, PushRouter: "GameMessagePush"})
+	for _, name := range []string{JSON, Msgpack} {
+		s, ok := Get(name)
+		if !ok {
+			t.Fatalf("serializer %s not found", name)
+		}
+		data, err := FromJSON(src, s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var msg pushMsg
+		if err := s.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if msg.Type != 401 || msg.PushRouter != "GameMessagePush" {
+			t.Fatalf("%s: unexpected message %+v", name, msg)
+		}
+		back, err := ToJSON(data, s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var a, b any
+		_ = json.Unmarshal(src, &a)
+		_ = json.Unmarshal(back, &b)
+		if ja, _ := json.Marshal(a); string(ja) != mustJSON(b) {
+			t.Fatalf("%s: round trip mismatch: %s != %s", name, ja, back)
+		}
+	}
+}
+
+func mustJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// proto 消息按 proto 定义编码：Any 展开，int64 保持整数；推送的 protobuf 编码是 Any
+func TestMarshalPush(t *testing.T) {
+	push := wrapperspb.Int64(1 << 40)
+	data, payloads, err := MarshalPush(push)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"value":1099511627776}` {
+		t.Fatalf("unexpected json: %s", data)
+	}
+	pb, _ := Get(Protobuf)
+	mp, _ := Get(Msgpack)
+	var a anypb.Any
+	if err := pb.Unmarshal(payloads[Protobuf], &a); err != nil {
+		t.Fatal(err)
+	}
+	var v wrapperspb.Int64Value
+	if err := a.UnmarshalTo(&v); err != nil || v.Value != 1<<40 {
+		t.Fatalf("unexpected protobuf payload: %v %v", &v, err)
+	}
+	var m map[string]any
+	if err := mp.Unmarshal(payloads[Msgpack], &m); err != nil || m["value"] != int64(1<<40) {
+		t.Fatalf("unexpected msgpack payload: %v %v", m, err)
+	}
+	wrapped, _ := anypb.New(push)
+	if b, _ := Default().Marshal(wrapped); string(b) != string(data) {
+		t.Fatalf("any should be expanded: %s", b)
+	}
+	// 没有 proto 定义的推送没有 protobuf 编码，protobuf 连接收到 json
+	_, payloads, _ = MarshalPush(pushMsg{Type: 401})
+	if _, ok := payloads[Protobuf]; ok {
+		t.Fatal("non-proto push should not have a protobuf payload")
+	}
+	if b, _ := FromJSON(data, pb); string(b) != string(data) {
+		t.Fatalf("protobuf connection should get json for schemaless bodies: %s", b)
+	}
+}
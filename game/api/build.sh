@@ -0,0 +1,4 @@
+#!/usr/bin/env bash
+
+# 生成到 game/pb/<go_package>
+protoc --go_out=../.. *.proto
@@ -6,6 +6,7 @@ import (
 	"context"
 	"core/repo"
 	"fmt"
+	"framework/directory"
 	"framework/node"
 	"game/route"
 	"os"
@@ -25,8 +26,12 @@ func Run(ctx context.Context, serverId string) error {
 		exit = n.Close
 		// 初始化数据库
 		manager := repo.New()
-		// 注册路由
-		n.RegisterHandler(route.Register(manager))
+		// 用户在线目录，推送到其他 connector 上的用户
+		n.SetPresence(directory.NewRedisPresence(manager.Redis))
+		// 注册路由，所有路由都经过 panic 恢复、统计和日志
+		n.Use(node.Recover(), node.Metrics(), node.Logger())
+		n.RegisterHandler(route.Register(manager, serverId))
+		n.RegisterRoomRoutes(route.RoomRoutes())
 		n.Run(serverId)
 	}()
 	// 优雅启停 遇到：中断 退出 中止 挂断信号 先执行清理操作，再退出
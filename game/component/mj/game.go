@@ -4,6 +4,7 @@ import (
 	"common/logs"
 	"common/utils"
 	"encoding/json"
+	"framework/protocol"
 	"framework/remote"
 	"game/component/base"
 	"game/component/mj/mp"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type GameFrame struct {
@@ -55,7 +57,7 @@ func initGameData(rule proto.GameRule) *GameData {
 }
 
 // 返回游戏数据、游戏场景，隐藏其他玩家的牌
-func (g *GameFrame) GetGameData(session *remote.Session) any {
+func (g *GameFrame) GetGameData(session *remote.Session) protoreflect.ProtoMessage {
 	userChairId := g.r.GetUsers()[session.GetUid()].ChairID
 	var gameData GameData
 	copier.CopyWithOption(gameData, g.gameData, copier.Option{DeepCopy: true, IgnoreEmpty: true})
@@ -78,7 +80,7 @@ func (g *GameFrame) GetGameData(session *remote.Session) any {
 			gameData.RestCardsCount = 9*3*4 + 8
 		}
 	}
-	return gameData
+	return gameDataMessage(&gameData)
 }
 
 // 开始游戏：1.（摇骰子阶段）游戏状态修改并推送；2.庄家推送；3.摇骰子推送；4.发牌推送；10.局数推进推送
@@ -123,9 +125,9 @@ func (g *GameFrame) sendGameStatus(gameStatus GameStatus, tick int, session *rem
 	g.ServerMessagePush(g.r.GetAllUid(), GameStatusPushData(gameStatus, tick), session)
 }
 
-func (g *GameFrame) ServerMessagePush(users []string, data any, session *remote.Session) {
+func (g *GameFrame) ServerMessagePush(users []string, data protoreflect.ProtoMessage, session *remote.Session) {
 	// 通过 nats 发送消息到 connector，connector 推送消息到客户端
-	session.Push(users, data, "ServerMessagePush")
+	session.Push(users, data, protocol.ServerMessagePush)
 }
 
 // 5.剩余牌数推送；6.开始游戏状态推送；7.拿牌推送；8.剩余牌数推送；
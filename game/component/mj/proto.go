@@ -1,6 +1,13 @@
 package mj
 
-import "game/component/mj/mp"
+import (
+	"game/component/mj/mp"
+	"game/component/proto"
+	"game/pb/mjpb"
+	"game/pb/roompb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
 
 type MessageReq struct {
 	Type int         `json:"type"`
@@ -123,120 +130,127 @@ const (
 	GameGetCardPush        = 415 //拿牌推送
 )
 
-func GameStatusPushData(gameStatus GameStatus, tick int) any {
-	return map[string]any{
-		"type": GameStatusPush,
-		"data": map[string]any{
-			"gameStatus": gameStatus,
-			"tick":       tick,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func gamePush(t int, data protoreflect.ProtoMessage) *roompb.MessagePush {
+	return proto.MessagePush("GameMessagePush", t, data)
 }
 
-func GameBankerPushData(bankerChairId int) any {
-	return map[string]any{
-		"type": GameBankerPush,
-		"data": map[string]any{
-			"bankerChairID": bankerChairId,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameStatusPushData(gameStatus GameStatus, tick int) *roompb.MessagePush {
+	return gamePush(GameStatusPush, &mjpb.GameStatusData{
+		GameStatus: int32(gameStatus),
+		Tick:       int32(tick),
+	})
 }
 
-func GameDicesPushData(dice1, dice2 int) any {
-	return map[string]any{
-		"type": GameDicesPush,
-		"data": map[string]any{
-			"dice1": dice1,
-			"dice2": dice2,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameBankerPushData(bankerChairId int) *roompb.MessagePush {
+	return gamePush(GameBankerPush, &mjpb.GameBankerData{
+		BankerChairID: int32(bankerChairId),
+	})
 }
 
-func GameSendCardsPushData(handCards [][]mp.CardID, chairID int) any {
-	return map[string]any{
-		"type": GameSendCardsPush,
-		"data": map[string]any{
-			"handCards": handCards,
-			"chairID":   chairID,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameDicesPushData(dice1, dice2 int) *roompb.MessagePush {
+	return gamePush(GameDicesPush, &mjpb.GameDicesData{
+		Dice1: int32(dice1),
+		Dice2: int32(dice2),
+	})
 }
 
-func GameRestCardsCountPushData(restCardsCount int) any {
-	return map[string]any{
-		"type": GameRestCardsCountPush,
-		"data": map[string]any{
-			"restCardsCount": restCardsCount,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameSendCardsPushData(handCards [][]mp.CardID, chairID int) *roompb.MessagePush {
+	return gamePush(GameSendCardsPush, &mjpb.GameSendCardsData{
+		HandCards: proto.IntLists(handCards),
+		ChairID:   int32(chairID),
+	})
 }
 
-func GameBureauPushData(CurBureau int) any {
-	return map[string]any{
-		"type": GameBureauPush,
-		"data": map[string]any{
-			"CurBureau": CurBureau,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameRestCardsCountPushData(restCardsCount int) *roompb.MessagePush {
+	return gamePush(GameRestCardsCountPush, &mjpb.GameRestCardsCountData{
+		RestCardsCount: int32(restCardsCount),
+	})
+}
+
+func GameBureauPushData(CurBureau int) *roompb.MessagePush {
+	return gamePush(GameBureauPush, &mjpb.GameBureauData{
+		CurBureau: int32(CurBureau),
+	})
 }
 
 // chairID 摸牌的玩家席位，card 新摸的牌（如果没有摸牌，就传 1-35 以外的数字）
-func GameTurnPushData(chairID int, card mp.CardID, tick int, operateArray []OperateType) any {
-	var c any
+func GameTurnPushData(chairID int, card mp.CardID, tick int, operateArray []OperateType) *roompb.MessagePush {
+	data := &mjpb.GameTurnData{
+		ChairID:      int32(chairID),
+		Tick:         int32(tick),
+		OperateArray: proto.Int32s(operateArray),
+	}
 	if card > 0 && card < 36 {
-		c = card
+		c := int32(card)
+		data.Card = &c
 	}
+	return gamePush(GameTurnPush, data)
+}
 
-	return map[string]any{
-		"type": GameTurnPush,
-		"data": map[string]any{
-			"chairID":      chairID,
-			"card":         c,
-			"tick":         tick,
-			"operateArray": operateArray,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameChatPushData(chairID, t int, msg string, recipientID int) *roompb.MessagePush {
+	return gamePush(GameChatPush, &mjpb.GameChatData{
+		ChairID:     int32(chairID),
+		Type:        int32(t),
+		Msg:         msg,
+		RecipientID: int32(recipientID),
+	})
 }
 
-func GameChatPushData(chairID, t int, msg string, recipientID int) any {
-	return map[string]any{
-		"type": GameChatPush,
-		"data": map[string]any{
-			"chairID":     chairID,
-			"type":        t,
-			"msg":         msg,
-			"recipientID": recipientID,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameTurnOperatePushData(chairID int, card mp.CardID, operate OperateType, success bool) *roompb.MessagePush {
+	return gamePush(GameTurnOperatePush, &mjpb.GameTurnOperateData{
+		ChairID: int32(chairID),
+		Card:    int32(card),
+		Operate: int32(operate),
+		Success: success,
+	})
 }
 
-func GameTurnOperatePushData(chairID int, card mp.CardID, operate OperateType, success bool) any {
-	return map[string]any{
-		"type": GameTurnOperatePush,
-		"data": map[string]any{
-			"chairID": chairID,
-			"card":    card,
-			"operate": operate,
-			"success": success,
-		},
-		"pushRouter": "GameMessagePush",
+func GameResultPushData(result GameResult) *roompb.MessagePush {
+	return gamePush(GameResultPush, &mjpb.GameResultData{
+		Result: gameResultMessage(&result),
+	})
+}
+
+func gameResultMessage(r *GameResult) *mjpb.GameResult {
+	if r == nil {
+		return nil
+	}
+	maCards := make([]*mjpb.MyMaCard, len(r.MyMaCards))
+	for i, c := range r.MyMaCards {
+		maCards[i] = &mjpb.MyMaCard{Card: int32(c.Card), Win: c.Win}
+	}
+	return &mjpb.GameResult{
+		Scores:          proto.Int32s(r.Scores),
+		HandCards:       proto.IntLists(r.HandCards),
+		MyMaCards:       maCards,
+		RestCards:       proto.Int32s(r.RestCards),
+		WinChairIDArray: proto.Int32s(r.WinChairIDArray),
+		GangChairID:     int32(r.GangChairID),
+		FangGangArray:   proto.Int32s(r.FangGangArray),
+		HuType:          int32(r.HuType),
 	}
 }
 
-func GameResultPushData(result GameResult) any {
-	return map[string]any{
-		"type": GameResultPush,
-		"data": map[string]any{
-			"result": result,
-		},
-		"pushRouter": "GameMessagePush",
+// 断线重连、进入房间时的场景数据
+func gameDataMessage(d *GameData) *mjpb.GameData {
+	records := make([]*mjpb.OperateRecord, len(d.OperateRecord))
+	for i, r := range d.OperateRecord {
+		records[i] = &mjpb.OperateRecord{ChairID: int32(r.ChairID), Card: int32(r.Card), Operate: int32(r.Operate)}
+	}
+	return &mjpb.GameData{
+		BankerChairID:  int32(d.BankerChairID),
+		ChairCount:     int32(d.ChairCount),
+		CurBureau:      int32(d.CurBureau),
+		GameStatus:     int32(d.GameStatus),
+		GameStarted:    d.GameStarted,
+		Tick:           int32(d.Tick),
+		MaxBureau:      int32(d.MaxBureau),
+		CurChairID:     int32(d.CurChairID),
+		UserTrustArray: proto.Int32s(d.UserTrustArray),
+		HandCards:      proto.IntLists(d.HandCards),
+		OperateArrays:  proto.IntLists(d.OperateArrays),
+		OperateRecord:  records,
+		RestCardsCount: int32(d.RestCardsCount),
+		Result:         gameResultMessage(d.Result),
 	}
 }
@@ -0,0 +1,69 @@
+package mj
+
+import (
+	"encoding/json"
+	"framework/serializer"
+	"game/component/mj/mp"
+	"game/pb/mjpb"
+	"game/pb/roompb"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// 推送改为 proto 消息后，json 格式和原来 map 组装的一致（空的 repeated 为 []）
+func TestPushJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		push any
+		want string
+	}{
+		{"status", GameStatusPushData(Dices, 3), `{"data":{"gameStatus":1,"tick":3},"pushRouter":"GameMessagePush","type":401}`},
+		{"sendCards", GameSendCardsPushData([][]mp.CardID{{1, 2}, {36, 36}}, 1), `{"data":{"chairID":1,"handCards":[[1,2],[36,36]]},"pushRouter":"GameMessagePush","type":404}`},
+		{"bureau", GameBureauPushData(2), `{"data":{"CurBureau":2},"pushRouter":"GameMessagePush","type":409}`},
+		{"turn", GameTurnPushData(1, 11, 30, []OperateType{Peng, Guo}), `{"data":{"card":11,"chairID":1,"operateArray":[3,7],"tick":30},"pushRouter":"GameMessagePush","type":406}`},
+		{"turnNoCard", GameTurnPushData(1, 0, 30, nil), `{"data":{"card":null,"chairID":1,"operateArray":[],"tick":30},"pushRouter":"GameMessagePush","type":406}`},
+		{"operate", GameTurnOperatePushData(2, 21, Peng, true), `{"data":{"card":21,"chairID":2,"operate":3,"success":true},"pushRouter":"GameMessagePush","type":407}`},
+		{"result", GameResultPushData(GameResult{Scores: []int{1, -1}, HandCards: [][]mp.CardID{{1}, {2}}, MyMaCards: []MyMaCard{}, WinChairIDArray: []int{0}, HuType: HuZhi}),
+			`{"data":{"result":{"scores":[1,-1],"handCards":[[1],[2]],"myMaCards":[],"restCards":[],"winChairIDArray":[0],"gangChairID":0,"fangGangArray":[],"huType":2}},"pushRouter":"GameMessagePush","type":408}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := serializer.Default().Marshal(tt.push)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, want any
+			_ = json.Unmarshal(data, &got)
+			_ = json.Unmarshal([]byte(tt.want), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %s\nwant %s", data, tt.want)
+			}
+		})
+	}
+}
+
+// protobuf 连接收到的是 Any，按 type_url 解出外层消息，再解出 data
+func TestPushProtobuf(t *testing.T) {
+	_, payloads, err := serializer.MarshalPush(GameTurnPushData(1, 0, 30, []OperateType{Peng}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, _ := serializer.Get(serializer.Protobuf)
+	var a anypb.Any
+	if err := pb.Unmarshal(payloads[serializer.Protobuf], &a); err != nil {
+		t.Fatal(err)
+	}
+	var push roompb.MessagePush
+	if err := a.UnmarshalTo(&push); err != nil {
+		t.Fatal(err)
+	}
+	var turn mjpb.GameTurnData
+	if err := push.Data.UnmarshalTo(&turn); err != nil {
+		t.Fatal(err)
+	}
+	if push.Type != GameTurnPush || turn.ChairID != 1 || turn.Card != nil || len(turn.OperateArray) != 1 {
+		t.Fatalf("unexpected push: %v %v", &push, &turn)
+	}
+}
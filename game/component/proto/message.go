@@ -0,0 +1,113 @@
+package proto
+
+import (
+	"common/logs"
+	"game/pb/roompb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// 推送的消息定义在 game/api/*.proto，这里把房间的数据转换为对应的消息
+
+// MessagePush RoomMessagePush、GameMessagePush 的外层，data 放在 google.protobuf.Any 中，
+// json、msgpack 编码时直接展开，和原来 {"type", "data", "pushRouter"} 的格式一致
+func MessagePush(pushRouter string, t int, data protoreflect.ProtoMessage) *roompb.MessagePush {
+	return &roompb.MessagePush{
+		Type:       int32(t),
+		Data:       anyMessage(data),
+		PushRouter: pushRouter,
+	}
+}
+
+func anyMessage(m protoreflect.ProtoMessage) *anypb.Any {
+	a, err := anypb.New(m)
+	if err != nil {
+		logs.Error("marshal %s err: %v", m.ProtoReflect().Descriptor().FullName(), err)
+		return nil
+	}
+	return a
+}
+
+func RoomUserMessage(u *RoomUser) *roompb.RoomUser {
+	return &roompb.RoomUser{
+		UserInfo: &roompb.UserInfo{
+			Uid:          u.UserInfo.Uid,
+			Nickname:     u.UserInfo.Nickname,
+			Avatar:       u.UserInfo.Avatar,
+			Gold:         u.UserInfo.Gold,
+			FrontendId:   u.UserInfo.FrontendId,
+			Address:      u.UserInfo.Address,
+			Location:     u.UserInfo.Location,
+			LastLoginIP:  u.UserInfo.LastLoginIP,
+			Sex:          int32(u.UserInfo.Sex),
+			Score:        int32(u.UserInfo.Score),
+			SpreaderID:   u.UserInfo.SpreaderID,
+			ProhibitGame: u.UserInfo.ProhibitGame,
+			RoomID:       u.UserInfo.RoomID,
+		},
+		ChairID:    int32(u.ChairID),
+		UserStatus: int32(u.UserStatus),
+	}
+}
+
+func RoomCreatorMessage(c *RoomCreator) *roompb.RoomCreator {
+	if c == nil {
+		return nil
+	}
+	return &roompb.RoomCreator{
+		Uid:         c.Uid,
+		CreatorType: int32(c.CreatorType),
+	}
+}
+
+func GameRuleMessage(rule GameRule) *roompb.GameRule {
+	return &roompb.GameRule{
+		AddScores:      Int32s(rule.AddScores),
+		BaseScore:      int32(rule.BaseScore),
+		Bureau:         int32(rule.Bureau),
+		CanEnter:       rule.CanEnter,
+		CanTrust:       rule.CanTrust,
+		Chunniunai:     rule.Chunniunai,
+		CanWatch:       rule.CanWatch,
+		Cuopai:         rule.Cuopai,
+		GameFrameType:  int32(rule.GameFrameType),
+		GameType:       int32(rule.GameType),
+		Ma:             int32(rule.Ma),
+		MaxPlayerCount: int32(rule.MaxPlayerCount),
+		MinPlayerCount: int32(rule.MinPlayerCount),
+		PayDiamond:     int32(rule.PayDiamond),
+		PayType:        int32(rule.PayType),
+		Qidui:          rule.Qidui,
+		RoomType:       int32(rule.RoomType),
+		Yuyin:          rule.Yuyin,
+		TrustTm:        int32(rule.TrustTm),
+		Fangzuobi:      rule.Fangzuobi,
+		MaxScore:       int32(rule.MaxScore),
+		RoundType:      int32(rule.RoundType),
+	}
+}
+
+// Int32s 牌、座位、状态等整数数组转换为消息中的 repeated int32
+func Int32s[T ~int](s []T) []int32 {
+	if s == nil {
+		return nil
+	}
+	r := make([]int32, len(s))
+	for i, v := range s {
+		r[i] = int32(v)
+	}
+	return r
+}
+
+// IntLists 二维数组（手牌等）转换为 repeated IntList
+func IntLists[T ~int](rows [][]T) []*roompb.IntList {
+	if rows == nil {
+		return nil
+	}
+	r := make([]*roompb.IntList, len(rows))
+	for i, row := range rows {
+		r[i] = &roompb.IntList{Values: Int32s(row)}
+	}
+	return r
+}
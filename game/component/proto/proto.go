@@ -1,5 +1,11 @@
 package proto
 
+import (
+	"game/pb/roompb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
 // sz 赢三张、hz 红中麻将
 type GameRule struct {
 	AddScores      []int `json:"addScores"`      //加注分
@@ -72,62 +78,73 @@ const (
 	UserChangeSeatPush                          = 420
 )
 
-func UpdateUserInfoPush(roomId string) any {
-	pushMsg := map[string]any{
-		"roomId":     roomId,
-		"pushRouter": "UpdateUserInfoPush",
+func UpdateUserInfoPush(roomId string) *roompb.UpdateUserInfoPush {
+	return &roompb.UpdateUserInfoPush{
+		RoomId:     roomId,
+		PushRouter: "UpdateUserInfoPush",
 	}
-	return pushMsg
 }
 
-func UserLeaveRoomPushData(user *RoomUser) any {
-	pushMsg := map[string]any{
-		"type":       UserLeaveRoomPush,
-		"pushRouter": "RoomMessagePush",
-		"data": map[string]any{
-			"roomUserInfo": user,
-		},
+func SelfEntryRoomPushData(gameType int) *roompb.SelfEntryRoomPush {
+	return &roompb.SelfEntryRoomPush{
+		GameType:   int32(gameType),
+		PushRouter: "SelfEntryRoomPush",
 	}
-	return pushMsg
 }
 
-func UserReadyPushData(chairID int) any {
-	pushMsg := map[string]any{
-		"type":       UserReadyPush,
-		"pushRouter": "RoomMessagePush",
-		"data": map[string]any{
-			"chairID": chairID,
-		},
-	}
-	return pushMsg
+func UserLeaveRoomPushData(user *RoomUser) *roompb.MessagePush {
+	return MessagePush("RoomMessagePush", UserLeaveRoomPush, &roompb.UserEntryData{
+		RoomUserInfo: RoomUserMessage(user),
+	})
 }
 
-func OtherUserEntryRoomPushData(user *RoomUser) any {
-	pushMsg := map[string]any{
-		"type":       OtherUserEntryRoomPush,
-		"pushRouter": "RoomMessagePush",
-		"data": map[string]any{
-			"roomUserInfo": user,
-		},
-	}
-	return pushMsg
+func UserReadyPushData(chairID int) *roompb.MessagePush {
+	return MessagePush("RoomMessagePush", UserReadyPush, &roompb.UserReadyData{
+		ChairID: int32(chairID),
+	})
+}
+
+func OtherUserEntryRoomPushData(user *RoomUser) *roompb.MessagePush {
+	return MessagePush("RoomMessagePush", OtherUserEntryRoomPush, &roompb.UserEntryData{
+		RoomUserInfo: RoomUserMessage(user),
+	})
 }
 
 type DismissPushData struct {
-	NameArr    []string `json:"nameArr"`
-	ChairIDArr []any    `json:"chairIDArr"` // 如果对方是第一次弹出解散框 any == nil
-	AvatarArr  []string `json:"avatarArr"`
-	OnlineArr  []bool   `json:"onlineArr"`
-	AskChairId int      `json:"askChairId"`
-	Tm         int      `json:"tm"` // 倒计时
-	ScoreArr   []int    `json:"scoreArr"`
+	NameArr    []string
+	ChairIDArr []bool // 同意解散的座位为 true
+	AvatarArr  []string
+	OnlineArr  []bool
+	AskChairId int
+	Tm         int // 倒计时
+	ScoreArr   []int
+}
+
+func AskForDismissPushData(data *DismissPushData) *roompb.MessagePush {
+	return MessagePush("RoomMessagePush", AskForDismissPush, &roompb.DismissData{
+		NameArr:    data.NameArr,
+		ChairIDArr: data.ChairIDArr,
+		AvatarArr:  data.AvatarArr,
+		OnlineArr:  data.OnlineArr,
+		AskChairId: int32(data.AskChairId),
+		Tm:         int32(data.Tm),
+		ScoreArr:   Int32s(data.ScoreArr),
+	})
 }
 
-func AskForDismissPushData(data *DismissPushData) any {
-	pushMsg := map[string]any{
-		"type":       AskForDismissPush,
-		"pushRouter": "RoomMessagePush",
-		"data":       data,
+func RoomSceneInfoPushData(roomID string, creator *RoomCreator, rule GameRule, users []*RoomUser, gameData protoreflect.ProtoMessage) *roompb.MessagePush {
+	userArr := make([]*roompb.RoomUser, 0, len(users))
+	for _, u := range users {
+		userArr = append(userArr, RoomUserMessage(u))
+	}
+	scene := &roompb.RoomSceneData{
+		RoomID:          roomID,
+		RoomCreatorInfo: RoomCreatorMessage(creator),
+		GameRule:        GameRuleMessage(rule),
+		RoomUserInfoArr: userArr,
+	}
+	if gameData != nil {
+		scene.GameData = anyMessage(gameData)
 	}
-	return pushMsg
+	return MessagePush("RoomMessagePush", GetRoomSceneInfoPush, scene)
 }
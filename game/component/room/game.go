@@ -3,10 +3,13 @@ package room
 import (
 	"framework/remote"
 	"game/component/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type GameFrame interface {
-	GetGameData(session *remote.Session) any
+	// GetGameData 场景数据，game/api 中定义的消息
+	GetGameData(session *remote.Session) protoreflect.ProtoMessage
 	StartGame(session *remote.Session, user *proto.RoomUser)
 	GameMessageHandler(user *proto.RoomUser, session *remote.Session, msg []byte)
 }
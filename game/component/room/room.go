@@ -5,6 +5,7 @@ import (
 	"common/logs"
 	"core/models/entity"
 	"framework/msError"
+	"framework/protocol"
 	"framework/remote"
 	"game/component/base"
 	"game/component/mj"
@@ -13,6 +14,8 @@ import (
 	"game/models/request"
 	"sync"
 	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Room struct {
@@ -58,21 +61,11 @@ func (r *Room) UserEntryRoom(session *remote.Session, user *entity.User) *msErro
 }
 
 func (r *Room) updateUserInfoRoomPush(session *remote.Session, uid string) {
-	pushMsg := map[string]any{
-		"roomId":     r.Id,
-		"pushRouter": "UpdateUserInfoPush",
-	}
-	// 通过 nats 发送消息到 connector，connector 推送消息到客户端
-	session.Push([]string{uid}, pushMsg, "ServerMessagePush")
+	r.ServerMessagePush([]string{uid}, proto.UpdateUserInfoPush(r.Id), session)
 }
 
 func (r *Room) SelfEntryRoomPush(session *remote.Session, uid string) {
-	pushMsg := map[string]any{
-		"gameType":   r.GameRule.GameType,
-		"pushRouter": "SelfEntryRoomPush",
-	}
-	// 通过 nats 发送消息到 connector，connector 推送消息到客户端
-	session.Push([]string{uid}, pushMsg, "ServerMessagePush")
+	r.ServerMessagePush([]string{uid}, proto.SelfEntryRoomPushData(r.GameRule.GameType), session)
 }
 
 func (r *Room) RoomMessageHandler(session *remote.Session, req request.RoomMessageReq) {
@@ -87,23 +80,12 @@ func (r *Room) RoomMessageHandler(session *remote.Session, req request.RoomMessa
 }
 
 func (r *Room) GetRoomSceneInfoPush(session *remote.Session) {
-	roomUserInfoArr := make([]*proto.RoomUser, len(r.users))
+	roomUserInfoArr := make([]*proto.RoomUser, 0, len(r.users))
 	for _, v := range r.users {
 		roomUserInfoArr = append(roomUserInfoArr, v)
 	}
-
-	data := map[string]any{
-		"type":       proto.GetRoomSceneInfoPush,
-		"pushRouter": "RoomMessagePush",
-		"data": map[string]any{
-			"roomID":          r.Id,
-			"roomCreatorInfo": r.RoomCreator,
-			"gameRule":        r.GameRule,
-			"roomUserInfoArr": roomUserInfoArr,
-			"gameData":        r.GameFrame.GetGameData(session),
-		},
-	}
-	session.Push([]string{session.GetUid()}, data, "ServerMessagePush")
+	data := proto.RoomSceneInfoPushData(r.Id, r.RoomCreator, r.GameRule, roomUserInfoArr, r.GameFrame.GetGameData(session))
+	r.ServerMessagePush([]string{session.GetUid()}, data, session)
 }
 
 func (r *Room) addKickScheduleEvent(session *remote.Session, uid string) {
@@ -134,9 +116,9 @@ func (r *Room) addKickScheduleEvent(session *remote.Session, uid string) {
 	})
 }
 
-func (r *Room) ServerMessagePush(users []string, data any, session *remote.Session) {
+func (r *Room) ServerMessagePush(users []string, data protoreflect.ProtoMessage, session *remote.Session) {
 	// 通过 nats 发送消息到 connector，connector 推送消息到客户端
-	session.Push(users, data, "ServerMessagePush")
+	session.Push(users, data, protocol.ServerMessagePush)
 }
 
 func (r *Room) kickUser(user *proto.RoomUser, session *remote.Session) {
@@ -317,7 +299,7 @@ func (r *Room) askForDismiss(session *remote.Session, exist bool) {
 		r.askDismiss[user.ChairID] = struct{}{}
 
 		nameArr := make([]string, len(r.users))
-		chairIDArr := make([]any, len(r.users))
+		chairIDArr := make([]bool, len(r.users))
 		avatarArr := make([]string, len(r.users))
 		onlineArr := make([]bool, len(r.users))
 		for _, roomUser := range r.users {
@@ -352,7 +334,7 @@ func (r *Room) askForDismiss(session *remote.Session, exist bool) {
 	} else {
 		// 不同意解散
 		nameArr := make([]string, len(r.users))
-		chairIDArr := make([]any, len(r.users))
+		chairIDArr := make([]bool, len(r.users))
 		avatarArr := make([]string, len(r.users))
 		onlineArr := make([]bool, len(r.users))
 		for _, roomUser := range r.users {
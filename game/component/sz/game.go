@@ -4,12 +4,14 @@ import (
 	"common/logs"
 	"common/utils"
 	"encoding/json"
+	"framework/protocol"
 	"framework/remote"
 	"game/component/base"
 	"game/component/proto"
 	"time"
 
 	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type GameFrame struct {
@@ -46,7 +48,7 @@ func initGameData(rule proto.GameRule) *GameData {
 }
 
 // 返回游戏数据、游戏场景，用户已看牌，则返回牌。并且隐藏其他用户的牌
-func (g *GameFrame) GetGameData(session *remote.Session) any {
+func (g *GameFrame) GetGameData(session *remote.Session) protoreflect.ProtoMessage {
 	user := g.r.GetUsers()[session.GetUid()]
 	// 深 copy
 	var gameData GameData
@@ -66,12 +68,12 @@ func (g *GameFrame) GetGameData(session *remote.Session) any {
 	if g.gameData.LookCards[user.ChairID] == 1 {
 		gameData.HandCards[user.ChairID] = g.gameData.HandCards[user.ChairID]
 	}
-	return gameData
+	return gameDataMessage(&gameData)
 }
 
-func (g *GameFrame) ServerMessagePush(users []string, data any, session *remote.Session) {
+func (g *GameFrame) ServerMessagePush(users []string, data protoreflect.ProtoMessage, session *remote.Session) {
 	// 通过 nats 发送消息到 connector，connector 推送消息到客户端
-	session.Push(users, data, "ServerMessagePush")
+	session.Push(users, data, protocol.ServerMessagePush)
 }
 
 func (g *GameFrame) StartGame(session *remote.Session, user *proto.RoomUser) {
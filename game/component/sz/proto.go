@@ -1,5 +1,13 @@
 package sz
 
+import (
+	"game/component/proto"
+	"game/pb/roompb"
+	"game/pb/szpb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
 type MessageReq struct {
 	Type int         `json:"type"`
 	Data MessageData `json:"data"`
@@ -138,141 +146,165 @@ const (
 	GameReviewPush      = 416
 )
 
-func UpdateUserInfoPushGold(gold int64) any {
-	return map[string]any{
-		"gold":       gold,
-		"pushRouter": "UpdateUserInfoPush",
-	}
+type GameResult struct {
+	Winners   []int   `json:"winners"`
+	WinScores []int   `json:"winScores"`
+	HandCards [][]int `json:"handCards"`
+	CurScores []int   `json:"curScores"`
+	Losers    []int   `json:"losers"`
 }
 
-func GameBankerPushData(bankerChairId int) any {
-	return map[string]any{
-		"type": GameBankerPush,
-		"data": map[string]any{
-			"bankerChairID": bankerChairId,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func gamePush(t int, data protoreflect.ProtoMessage) *roompb.MessagePush {
+	return proto.MessagePush("GameMessagePush", t, data)
 }
 
-func GameBureauPushData(curBureau int) any {
-	return map[string]any{
-		"type": GameBureauPush,
-		"data": map[string]any{
-			"curBureau": curBureau,
-		},
-		"pushRouter": "GameMessagePush",
+func UpdateUserInfoPushGold(gold int64) *szpb.UpdateUserInfoGoldPush {
+	return &szpb.UpdateUserInfoGoldPush{
+		Gold:       gold,
+		PushRouter: "UpdateUserInfoPush",
 	}
 }
 
-func GameStatusPushData(gameStatus GameStatus, tick int) any {
-	return map[string]any{
-		"type": GameStatusPush,
-		"data": map[string]any{
-			"gameStatus": gameStatus,
-			"tick":       tick,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameBankerPushData(bankerChairId int) *roompb.MessagePush {
+	return gamePush(GameBankerPush, &szpb.GameBankerData{
+		BankerChairID: int32(bankerChairId),
+	})
 }
 
-func GameSendCardsPushData(handCards [][]int) any {
-	return map[string]any{
-		"type": GameSendCardsPush,
-		"data": map[string]any{
-			"handCards": handCards,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameBureauPushData(curBureau int) *roompb.MessagePush {
+	return gamePush(GameBureauPush, &szpb.GameBureauData{
+		CurBureau: int32(curBureau),
+	})
 }
 
-func GamePourScorePushData(chairId, score, chairScore, scores, typ int) any {
-	return map[string]any{
-		"type": GamePourScorePush,
-		"data": map[string]any{
-			"chairID":    chairId,
-			"score":      score,
-			"chairScore": chairScore,
-			"scores":     scores,
-			"type":       typ,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameStatusPushData(gameStatus GameStatus, tick int) *roompb.MessagePush {
+	return gamePush(GameStatusPush, &szpb.GameStatusData{
+		GameStatus: int32(gameStatus),
+		Tick:       int32(tick),
+	})
 }
 
-func GameRoundPushData(round int) any {
-	return map[string]any{
-		"type": GameRoundPush,
-		"data": map[string]any{
-			"round": round,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameSendCardsPushData(handCards [][]int) *roompb.MessagePush {
+	return gamePush(GameSendCardsPush, &szpb.GameSendCardsData{
+		HandCards: proto.IntLists(handCards),
+	})
+}
+
+func GamePourScorePushData(chairId, score, chairScore, scores, typ int) *roompb.MessagePush {
+	return gamePush(GamePourScorePush, &szpb.GamePourScoreData{
+		ChairID:    int32(chairId),
+		Score:      int32(score),
+		ChairScore: int32(chairScore),
+		Scores:     int32(scores),
+		Type:       int32(typ),
+	})
+}
+
+func GameRoundPushData(round int) *roompb.MessagePush {
+	return gamePush(GameRoundPush, &szpb.GameRoundData{
+		Round: int32(round),
+	})
 }
 
 // ChairID 是当前可做操作的玩家的 chairId
-func GameTurnPushData(curChairID, curScore int) any {
-	return map[string]any{
-		"type": GameTurnPush,
-		"data": map[string]any{
-			"curChairID": curChairID,
-			"curScore":   curScore,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameTurnPushData(curChairID, curScore int) *roompb.MessagePush {
+	return gamePush(GameTurnPush, &szpb.GameTurnData{
+		CurChairID: int32(curChairID),
+		CurScore:   int32(curScore),
+	})
 }
 
-// 看牌
-func GameLookPushData(chairId int, cards []int, cuopai bool) any {
-	return map[string]any{
-		"type": GameLookPush,
-		"data": map[string]any{
-			"chairID": chairId,
-			"cards":   cards,
-			"cuopai":  cuopai,
-		},
-		"pushRouter": "GameMessagePush",
+// 看牌，cards 为 nil 时客户端看不到牌
+func GameLookPushData(chairId int, cards []int, cuopai bool) *roompb.MessagePush {
+	data := &szpb.GameLookData{
+		ChairID: int32(chairId),
+		Cuopai:  cuopai,
+	}
+	if cards != nil {
+		data.Cards = &roompb.IntList{Values: proto.Int32s(cards)}
 	}
+	return gamePush(GameLookPush, data)
 }
 
-func GameComparePushData(fromChairID, toChairID, winChairID, loseChairID int) any {
-	return map[string]any{
-		"type": GameComparePush,
-		"data": map[string]any{
-			"fromChairID": fromChairID,
-			"toChairID":   toChairID,
-			"winChairID":  winChairID,
-			"loseChairID": loseChairID,
-		},
-		"pushRouter": "GameMessagePush",
-	}
+func GameComparePushData(fromChairID, toChairID, winChairID, loseChairID int) *roompb.MessagePush {
+	return gamePush(GameComparePush, &szpb.GameCompareData{
+		FromChairID: int32(fromChairID),
+		ToChairID:   int32(toChairID),
+		WinChairID:  int32(winChairID),
+		LoseChairID: int32(loseChairID),
+	})
 }
 
-type GameResult struct {
-	Winners   []int   `json:"winners"`
-	WinScores []int   `json:"winScores"`
-	HandCards [][]int `json:"handCards"`
-	CurScores []int   `json:"curScores"`
-	Losers    []int   `json:"losers"`
+func GameResultPushData(result *GameResult) *roompb.MessagePush {
+	return gamePush(GameResultPush, &szpb.GameResultData{
+		Result: gameResultMessage(result),
+	})
+}
+
+func GameAbandonPushData(chairID int, status UserStatus) *roompb.MessagePush {
+	return gamePush(GameAbandonPush, &szpb.GameAbandonData{
+		ChairID:    int32(chairID),
+		UserStatus: int32(status),
+	})
 }
 
-func GameResultPushData(result *GameResult) any {
-	return map[string]any{
-		"type": GameResultPush,
-		"data": map[string]any{
-			"result": result,
-		},
-		"pushRouter": "GameMessagePush",
+func gameResultMessage(r *GameResult) *szpb.GameResult {
+	if r == nil {
+		return nil
+	}
+	return &szpb.GameResult{
+		Winners:   proto.Int32s(r.Winners),
+		WinScores: proto.Int32s(r.WinScores),
+		HandCards: proto.IntLists(r.HandCards),
+		CurScores: proto.Int32s(r.CurScores),
+		Losers:    proto.Int32s(r.Losers),
 	}
 }
 
-func GameAbandonPushData(chairID int, status UserStatus) any {
-	return map[string]any{
-		"type": GameAbandonPush,
-		"data": map[string]any{
-			"chairID":    chairID,
-			"userStatus": status,
-		},
-		"pushRouter": "GameMessagePush",
+// 断线重连、进入房间时的场景数据
+func gameDataMessage(d *GameData) *szpb.GameData {
+	winRecord := make(map[string]*szpb.UserWinRecord, len(d.UserWinRecord))
+	for uid, r := range d.UserWinRecord {
+		winRecord[uid] = &szpb.UserWinRecord{Uid: r.Uid, Nickname: r.Nickname, Avatar: r.Avatar, Score: int32(r.Score)}
+	}
+	reviews := make([]*szpb.BureauReview, len(d.ReviewRecord))
+	for i, r := range d.ReviewRecord {
+		reviews[i] = &szpb.BureauReview{
+			Uid:       r.Uid,
+			Cards:     proto.Int32s(r.Cards),
+			PourScore: int32(r.PourScore),
+			WinScore:  int32(r.WinScore),
+			NickName:  r.NickName,
+			Avatar:    r.Avatar,
+			IsBanker:  r.IsBanker,
+			IsAbandon: r.IsAbandon,
+		}
+	}
+	result, _ := d.Result.(*GameResult)
+	return &szpb.GameData{
+		BankerChairID:   int32(d.BankerChairID),
+		ChairCount:      int32(d.ChairCount),
+		CurBureau:       int32(d.CurBureau),
+		CurScore:        int32(d.CurScore),
+		CurScores:       proto.Int32s(d.CurScores),
+		GameStarter:     d.GameStarter,
+		GameStatus:      int32(d.GameStatus),
+		HandCards:       proto.IntLists(d.HandCards),
+		LookCards:       proto.Int32s(d.LookCards),
+		Loser:           proto.Int32s(d.Loser),
+		Winner:          proto.Int32s(d.Winner),
+		MaxBureau:       int32(d.MaxBureau),
+		PourScores:      proto.IntLists(d.PourScores),
+		GameType:        int32(d.GameType),
+		BaseScore:       int32(d.BaseScore),
+		Result:          gameResultMessage(result),
+		Round:           int32(d.Round),
+		Tick:            int32(d.Tick),
+		UserTrustArray:  d.UserTrustArray,
+		UserStatusArray: proto.Int32s(d.UserStatusArray),
+		UserWinRecord:   winRecord,
+		ReviewRecord:    reviews,
+		TrustTmArray:    proto.Int32s(d.TrustTmArray),
+		CurChairID:      int32(d.CurChairID),
 	}
 }
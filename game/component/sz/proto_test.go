@@ -0,0 +1,39 @@
+package sz
+
+import (
+	"encoding/json"
+	"framework/serializer"
+	"reflect"
+	"testing"
+)
+
+// 推送改为 proto 消息后，json 格式和原来 map 组装的一致
+func TestPushJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		push any
+		want string
+	}{
+		{"gold", UpdateUserInfoPushGold(1200), `{"gold":1200,"pushRouter":"UpdateUserInfoPush"}`},
+		{"pourScore", GamePourScorePushData(1, 2, 3, 4, 1), `{"data":{"chairID":1,"chairScore":3,"score":2,"scores":4,"type":1},"pushRouter":"GameMessagePush","type":404}`},
+		{"look", GameLookPushData(1, []int{3, 4, 5}, true), `{"data":{"cards":[3,4,5],"chairID":1,"cuopai":true},"pushRouter":"GameMessagePush","type":403}`},
+		{"lookHidden", GameLookPushData(1, nil, false), `{"data":{"cards":null,"chairID":1,"cuopai":false},"pushRouter":"GameMessagePush","type":403}`},
+		{"result", GameResultPushData(&GameResult{Winners: []int{0}, WinScores: []int{10, -10}, HandCards: [][]int{{1, 2, 3}, {4, 5, 6}}, CurScores: []int{5, 5}, Losers: []int{1}}),
+			`{"data":{"result":{"winners":[0],"winScores":[10,-10],"handCards":[[1,2,3],[4,5,6]],"curScores":[5,5],"losers":[1]}},"pushRouter":"GameMessagePush","type":407}`},
+		{"abandon", GameAbandonPushData(1, Abandon), `{"data":{"chairID":1,"userStatus":1},"pushRouter":"GameMessagePush","type":412}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := serializer.Default().Marshal(tt.push)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, want any
+			_ = json.Unmarshal(data, &got)
+			_ = json.Unmarshal([]byte(tt.want), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %s\nwant %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"common/biz"
 	"core/repo"
 	"core/service"
-	"encoding/json"
 	"fmt"
+	"framework/msError"
 	"framework/remote"
 	"game/logic"
 	"game/models/request"
@@ -17,42 +17,32 @@ type GameHandler struct {
 	userService *service.UserService
 }
 
-func (h *GameHandler) RoomMessageNotify(session *remote.Session, msg []byte) any {
-	if len(session.GetUid()) <= 0 {
-		return common.FailNoCtx(biz.InvalidUsers)
-	}
-	var req request.RoomMessageReq
-	if err := json.Unmarshal(msg, &req); err != nil {
-		return common.FailNoCtx(biz.RequestDataError)
-	}
+func (h *GameHandler) RoomMessageNotify(session *remote.Session, req *request.RoomMessageReq) (*common.Result, *msError.Error) {
 	// room 处理业务
 	roomId, ok := session.Get("roomId")
 	if !ok {
-		return common.FailNoCtx(biz.NotInRoom)
+		return nil, biz.NotInRoom
 	}
 	room := h.m.GetRoomById(fmt.Sprintf("%v", roomId))
 	if room == nil {
-		return common.FailNoCtx(biz.RoomNotExist)
+		return nil, biz.RoomNotExist
 	}
-	room.RoomMessageHandler(session, req)
-	return nil // Notify 消息不需要响应
+	room.RoomMessageHandler(session, *req)
+	return nil, nil // Notify 消息不需要响应
 }
 
-func (h *GameHandler) GameMessageNotify(session *remote.Session, msg []byte) any {
-	if len(session.GetUid()) <= 0 {
-		return common.FailNoCtx(biz.InvalidUsers)
-	}
+func (h *GameHandler) GameMessageNotify(session *remote.Session, req *request.GameMessageReq) (*common.Result, *msError.Error) {
 	// room 处理业务
 	roomId, ok := session.Get("roomId")
 	if !ok {
-		return common.FailNoCtx(biz.NotInRoom)
+		return nil, biz.NotInRoom
 	}
 	room := h.m.GetRoomById(fmt.Sprintf("%v", roomId))
 	if room == nil {
-		return common.FailNoCtx(biz.RoomNotExist)
+		return nil, biz.RoomNotExist
 	}
-	room.GameMessageHandler(session, msg)
-	return nil // Notify 消息不需要响应
+	room.GameMessageHandler(session, req.Raw)
+	return nil, nil // Notify 消息不需要响应
 }
 
 func NewGameHandler(r *repo.Manager, manager *logic.UnionManager) *GameHandler {
@@ -6,7 +6,7 @@ import (
 	"context"
 	"core/repo"
 	"core/service"
-	"encoding/json"
+	"framework/msError"
 	"framework/remote"
 	"game/logic"
 	"game/models/request"
@@ -17,63 +17,49 @@ type UnionHandler struct {
 	userService *service.UserService
 }
 
-func (u *UnionHandler) CreateRoom(session *remote.Session, msg []byte) any {
+func (u *UnionHandler) CreateRoom(session *remote.Session, req *request.CreateRoomReq) (*common.Result, *msError.Error) {
 	// union 联盟持有多个房间
 	// unionManager 管理多个联盟
 	// room 房间关联 game（接口 实现多个不同的游戏）
-	// 1.接收参数
+	// 1.接收参数，请求体已自动解码，路由注册了 Auth，uid 一定存在
 	uid := session.GetUid()
-	if len(uid) <= 0 {
-		return common.FailNoCtx(biz.InvalidUsers)
-	}
-
-	var req request.CreateRoomReq
-	if err := json.Unmarshal(msg, &req); err != nil {
-		return common.FailNoCtx(biz.RequestDataError)
-	}
 	// 2.根据 session 用户 id 查询用户的信息
 	user, err := u.userService.FindUserByUid(context.TODO(), uid)
 	if err != nil {
-		return common.FailNoCtx(err)
+		return nil, err
 	}
 	if user == nil {
-		return common.FailNoCtx(biz.InvalidUsers)
+		return nil, biz.InvalidUsers
 	}
 	// 3.根据游戏规则、游戏类型、用户信息 创建房间
 	// todo 检查 session 是否 已经存在 roomId，如果有则代表用户已经在房间中了，就不能再次创建房间
 	union := u.m.GetUnion(req.UnionID)
-	err = union.CreateRoom(u.userService, session, req, user)
+	err = union.CreateRoom(u.userService, session, *req, user)
 	if err != nil {
-		return common.FailNoCtx(err)
+		return nil, err
 	}
-	return common.SuccessNoCtx(nil)
+	res := common.SuccessNoCtx(nil)
+	return &res, nil
 }
 
-func (u *UnionHandler) JoinRoom(session *remote.Session, msg []byte) any {
-	// 1.接收参数
+func (u *UnionHandler) JoinRoom(session *remote.Session, req *request.JoinRoomReq) (*common.Result, *msError.Error) {
+	// 1.接收参数，请求体已自动解码并校验，路由注册了 Auth，uid 一定存在
 	uid := session.GetUid()
-	if len(uid) <= 0 {
-		return common.FailNoCtx(biz.InvalidUsers)
-	}
-
-	var req request.JoinRoomReq
-	if err := json.Unmarshal(msg, &req); err != nil {
-		return common.FailNoCtx(biz.RequestDataError)
-	}
 	// 2.根据 session 用户 id 查询用户的信息
 	user, err := u.userService.FindUserByUid(context.TODO(), uid)
 	if err != nil {
-		return common.FailNoCtx(err)
+		return nil, err
 	}
 	if user == nil {
-		return common.FailNoCtx(biz.InvalidUsers)
+		return nil, biz.InvalidUsers
 	}
 	// 获取房间
 	err = u.m.JoinRoom(session, req.RoomID, user)
 	if err != nil {
-		return common.FailNoCtx(err)
+		return nil, err
 	}
-	return common.SuccessNoCtx(nil)
+	res := common.SuccessNoCtx(nil)
+	return &res, nil
 }
 
 func NewUnionHandler(r *repo.Manager, manager *logic.UnionManager) *UnionHandler {
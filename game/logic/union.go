@@ -22,15 +22,23 @@ func (u *Union) CreateRoom(service *service.UserService, session *remote.Session
 	// 1.创建房间
 	roomId := u.m.CreateRoomId()
 	newRoom := room.NewRoom(roomId, req.UnionID, req.GameRule, u)
+	u.Lock()
 	u.Rooms[roomId] = newRoom
+	u.Unlock()
 	// 2.推送房间号到客户端
-	return newRoom.UserEntryRoom(session, user)
+	if err := newRoom.UserEntryRoom(session, user); err != nil {
+		// 创建失败，释放房间和房间号的归属
+		u.DismissRoom(roomId)
+		return err
+	}
+	return nil
 }
 
 func (u *Union) DismissRoom(roomId string) {
 	u.Lock()
 	defer u.Unlock()
 	delete(u.Rooms, roomId)
+	u.m.unbindRoom(roomId)
 }
 
 func NewUnion(m *UnionManager) *Union {
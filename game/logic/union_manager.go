@@ -2,8 +2,10 @@ package logic
 
 import (
 	"common/biz"
+	"common/logs"
 	"core/models/entity"
 	"fmt"
+	"framework/directory"
 	"framework/msError"
 	"framework/remote"
 	"game/component/room"
@@ -14,13 +16,48 @@ import (
 
 type UnionManager struct {
 	sync.RWMutex
-	unions map[int64]*Union
+	unions   map[int64]*Union
+	dir      directory.RoomDirectory // 记录房间所在的 game 节点，connector 据此路由
+	serverId string
 }
 
-func NewUnionManager() *UnionManager {
-	return &UnionManager{
-		unions: make(map[int64]*Union),
+func NewUnionManager(dir directory.RoomDirectory, serverId string) *UnionManager {
+	u := &UnionManager{
+		unions:   make(map[int64]*Union),
+		dir:      dir,
+		serverId: serverId,
 	}
+	if dir != nil {
+		go u.refreshRooms()
+	}
+	return u
+}
+
+// refreshRooms 定时续期本节点所有房间的归属，节点宕机后不再续期，归属在 RoomTTL 后失效
+func (u *UnionManager) refreshRooms() {
+	ticker := time.NewTicker(directory.RoomTTL / 3)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, roomId := range u.roomIds() {
+			if err := u.dir.Refresh(roomId, u.serverId); err != nil {
+				logs.Error("room directory refresh err: %v, roomId=%s", err, roomId)
+			}
+		}
+	}
+}
+
+func (u *UnionManager) roomIds() []string {
+	u.RLock()
+	defer u.RUnlock()
+	var ids []string
+	for _, union := range u.unions {
+		union.RLock()
+		for roomId := range union.Rooms {
+			ids = append(ids, roomId)
+		}
+		union.RUnlock()
+	}
+	return ids
 }
 
 func (u *UnionManager) GetUnion(unionId int64) *Union {
@@ -39,14 +76,38 @@ func (u *UnionManager) GetUnion(unionId int64) *Union {
 func (u *UnionManager) CreateRoomId() string {
 	// 随机数的方式创建
 	roomId := u.genRoomId()
-	for _, union := range u.unions {
-		if _, ok := union.Rooms[roomId]; ok {
-			return u.CreateRoomId()
-		}
+	if u.GetRoomById(roomId) != nil {
+		return u.CreateRoomId()
+	}
+	// 房间号在所有 game 节点间唯一，被其他节点占用时重新生成
+	if !u.bindRoom(roomId) {
+		return u.CreateRoomId()
 	}
 	return roomId
 }
 
+func (u *UnionManager) bindRoom(roomId string) bool {
+	if u.dir == nil {
+		return true
+	}
+	ok, err := u.dir.Bind(roomId, u.serverId)
+	if err != nil {
+		// 目录不可用时退化为单节点，房间请求可能路由不到本节点
+		logs.Error("room directory bind err: %v, roomId=%s", err, roomId)
+		return true
+	}
+	return ok
+}
+
+func (u *UnionManager) unbindRoom(roomId string) {
+	if u.dir == nil {
+		return
+	}
+	if err := u.dir.Unbind(roomId, u.serverId); err != nil {
+		logs.Error("room directory unbind err: %v, roomId=%s", err, roomId)
+	}
+}
+
 // 生成 6 位房间号
 func (u *UnionManager) genRoomId() string {
 	rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -55,9 +116,15 @@ func (u *UnionManager) genRoomId() string {
 	return fmt.Sprintf("%d", roomIdInt)
 }
 
+// GetRoomById 不同房间、用户的请求并发执行，联盟和房间的 map 都要加锁读取
 func (u *UnionManager) GetRoomById(roomId string) *room.Room {
+	u.RLock()
+	defer u.RUnlock()
 	for _, union := range u.unions {
-		if r, ok := union.Rooms[roomId]; ok {
+		union.RLock()
+		r, ok := union.Rooms[roomId]
+		union.RUnlock()
+		if ok {
 			return r
 		}
 	}
@@ -65,11 +132,10 @@ func (u *UnionManager) GetRoomById(roomId string) *room.Room {
 }
 
 func (u *UnionManager) JoinRoom(session *remote.Session, roomId string, user *entity.User) *msError.Error {
-	for _, union := range u.unions {
-		r, ok := union.Rooms[roomId]
-		if ok {
-			return r.JoinRoom(session, user)
-		}
+	// 进入房间时不持有联盟的锁，房间逻辑中会推送消息
+	r := u.GetRoomById(roomId)
+	if r == nil {
+		return biz.RoomNotExist
 	}
-	return biz.RoomNotExist
+	return r.JoinRoom(session, user)
 }
@@ -1,6 +1,9 @@
 package request
 
-import "game/component/proto"
+import (
+	"encoding/json"
+	"game/component/proto"
+)
 
 type RoomMessageReq struct {
 	Type proto.RoomMessageType `json:"type"`
@@ -11,3 +14,13 @@ type RoomMessageData struct {
 	IsReady bool `json:"isReady"`
 	IsExit  bool `json:"isExit"`
 }
+
+// GameMessageReq 游戏内的消息，格式由各个游戏（sz、mj）自己定义，这里只保留原始的 json 交给游戏解析
+type GameMessageReq struct {
+	Raw json.RawMessage
+}
+
+func (r *GameMessageReq) UnmarshalJSON(data []byte) error {
+	r.Raw = append(r.Raw[:0], data...)
+	return nil
+}
@@ -1,6 +1,10 @@
 package request
 
-import "game/component/proto"
+import (
+	"common/biz"
+	"framework/msError"
+	"game/component/proto"
+)
 
 type CreateRoomReq struct {
 	UnionID    int64          `json:"unionID"` // 若为 1 就是普通用户创建
@@ -11,3 +15,10 @@ type CreateRoomReq struct {
 type JoinRoomReq struct {
 	RoomID string `json:"roomID"`
 }
+
+func (r *JoinRoomReq) Validate() *msError.Error {
+	if len(r.RoomID) == 0 {
+		return biz.RequestDataError
+	}
+	return nil
+}
@@ -0,0 +1,1072 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.3
+// source: mj.proto
+
+package mjpb
+
+import (
+	roompb "game/pb/roompb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GameStatusData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameStatus    int32                  `protobuf:"varint,1,opt,name=gameStatus,proto3" json:"gameStatus,omitempty"`
+	Tick          int32                  `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameStatusData) Reset() {
+	*x = GameStatusData{}
+	mi := &file_mj_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStatusData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStatusData) ProtoMessage() {}
+
+func (x *GameStatusData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStatusData.ProtoReflect.Descriptor instead.
+func (*GameStatusData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GameStatusData) GetGameStatus() int32 {
+	if x != nil {
+		return x.GameStatus
+	}
+	return 0
+}
+
+func (x *GameStatusData) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+type GameBankerData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankerChairID int32                  `protobuf:"varint,1,opt,name=bankerChairID,proto3" json:"bankerChairID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameBankerData) Reset() {
+	*x = GameBankerData{}
+	mi := &file_mj_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameBankerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameBankerData) ProtoMessage() {}
+
+func (x *GameBankerData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameBankerData.ProtoReflect.Descriptor instead.
+func (*GameBankerData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GameBankerData) GetBankerChairID() int32 {
+	if x != nil {
+		return x.BankerChairID
+	}
+	return 0
+}
+
+type GameDicesData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dice1         int32                  `protobuf:"varint,1,opt,name=dice1,proto3" json:"dice1,omitempty"`
+	Dice2         int32                  `protobuf:"varint,2,opt,name=dice2,proto3" json:"dice2,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameDicesData) Reset() {
+	*x = GameDicesData{}
+	mi := &file_mj_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameDicesData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameDicesData) ProtoMessage() {}
+
+func (x *GameDicesData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameDicesData.ProtoReflect.Descriptor instead.
+func (*GameDicesData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GameDicesData) GetDice1() int32 {
+	if x != nil {
+		return x.Dice1
+	}
+	return 0
+}
+
+func (x *GameDicesData) GetDice2() int32 {
+	if x != nil {
+		return x.Dice2
+	}
+	return 0
+}
+
+type GameSendCardsData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HandCards     []*roompb.IntList      `protobuf:"bytes,1,rep,name=handCards,proto3" json:"handCards,omitempty"`
+	ChairID       int32                  `protobuf:"varint,2,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameSendCardsData) Reset() {
+	*x = GameSendCardsData{}
+	mi := &file_mj_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameSendCardsData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSendCardsData) ProtoMessage() {}
+
+func (x *GameSendCardsData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSendCardsData.ProtoReflect.Descriptor instead.
+func (*GameSendCardsData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GameSendCardsData) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *GameSendCardsData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+type GameRestCardsCountData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RestCardsCount int32                  `protobuf:"varint,1,opt,name=restCardsCount,proto3" json:"restCardsCount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GameRestCardsCountData) Reset() {
+	*x = GameRestCardsCountData{}
+	mi := &file_mj_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameRestCardsCountData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameRestCardsCountData) ProtoMessage() {}
+
+func (x *GameRestCardsCountData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameRestCardsCountData.ProtoReflect.Descriptor instead.
+func (*GameRestCardsCountData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GameRestCardsCountData) GetRestCardsCount() int32 {
+	if x != nil {
+		return x.RestCardsCount
+	}
+	return 0
+}
+
+type GameBureauData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurBureau     int32                  `protobuf:"varint,1,opt,name=CurBureau,proto3" json:"CurBureau,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameBureauData) Reset() {
+	*x = GameBureauData{}
+	mi := &file_mj_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameBureauData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameBureauData) ProtoMessage() {}
+
+func (x *GameBureauData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameBureauData.ProtoReflect.Descriptor instead.
+func (*GameBureauData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GameBureauData) GetCurBureau() int32 {
+	if x != nil {
+		return x.CurBureau
+	}
+	return 0
+}
+
+type GameTurnData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Card          *int32                 `protobuf:"varint,2,opt,name=card,proto3,oneof" json:"card,omitempty"` //没有摸牌时为 null
+	Tick          int32                  `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	OperateArray  []int32                `protobuf:"varint,4,rep,packed,name=operateArray,proto3" json:"operateArray,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameTurnData) Reset() {
+	*x = GameTurnData{}
+	mi := &file_mj_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameTurnData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameTurnData) ProtoMessage() {}
+
+func (x *GameTurnData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameTurnData.ProtoReflect.Descriptor instead.
+func (*GameTurnData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GameTurnData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GameTurnData) GetCard() int32 {
+	if x != nil && x.Card != nil {
+		return *x.Card
+	}
+	return 0
+}
+
+func (x *GameTurnData) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameTurnData) GetOperateArray() []int32 {
+	if x != nil {
+		return x.OperateArray
+	}
+	return nil
+}
+
+type GameChatData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Msg           string                 `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	RecipientID   int32                  `protobuf:"varint,4,opt,name=recipientID,proto3" json:"recipientID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameChatData) Reset() {
+	*x = GameChatData{}
+	mi := &file_mj_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameChatData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameChatData) ProtoMessage() {}
+
+func (x *GameChatData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameChatData.ProtoReflect.Descriptor instead.
+func (*GameChatData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameChatData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GameChatData) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *GameChatData) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *GameChatData) GetRecipientID() int32 {
+	if x != nil {
+		return x.RecipientID
+	}
+	return 0
+}
+
+type GameTurnOperateData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Card          int32                  `protobuf:"varint,2,opt,name=card,proto3" json:"card,omitempty"`
+	Operate       int32                  `protobuf:"varint,3,opt,name=operate,proto3" json:"operate,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameTurnOperateData) Reset() {
+	*x = GameTurnOperateData{}
+	mi := &file_mj_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameTurnOperateData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameTurnOperateData) ProtoMessage() {}
+
+func (x *GameTurnOperateData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameTurnOperateData.ProtoReflect.Descriptor instead.
+func (*GameTurnOperateData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GameTurnOperateData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GameTurnOperateData) GetCard() int32 {
+	if x != nil {
+		return x.Card
+	}
+	return 0
+}
+
+func (x *GameTurnOperateData) GetOperate() int32 {
+	if x != nil {
+		return x.Operate
+	}
+	return 0
+}
+
+func (x *GameTurnOperateData) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type MyMaCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          int32                  `protobuf:"varint,1,opt,name=card,proto3" json:"card,omitempty"`
+	Win           bool                   `protobuf:"varint,2,opt,name=win,proto3" json:"win,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MyMaCard) Reset() {
+	*x = MyMaCard{}
+	mi := &file_mj_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MyMaCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MyMaCard) ProtoMessage() {}
+
+func (x *MyMaCard) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MyMaCard.ProtoReflect.Descriptor instead.
+func (*MyMaCard) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MyMaCard) GetCard() int32 {
+	if x != nil {
+		return x.Card
+	}
+	return 0
+}
+
+func (x *MyMaCard) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+type OperateRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Card          int32                  `protobuf:"varint,2,opt,name=card,proto3" json:"card,omitempty"`
+	Operate       int32                  `protobuf:"varint,3,opt,name=operate,proto3" json:"operate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperateRecord) Reset() {
+	*x = OperateRecord{}
+	mi := &file_mj_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperateRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperateRecord) ProtoMessage() {}
+
+func (x *OperateRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperateRecord.ProtoReflect.Descriptor instead.
+func (*OperateRecord) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OperateRecord) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *OperateRecord) GetCard() int32 {
+	if x != nil {
+		return x.Card
+	}
+	return 0
+}
+
+func (x *OperateRecord) GetOperate() int32 {
+	if x != nil {
+		return x.Operate
+	}
+	return 0
+}
+
+type GameResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Scores          []int32                `protobuf:"varint,1,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	HandCards       []*roompb.IntList      `protobuf:"bytes,2,rep,name=handCards,proto3" json:"handCards,omitempty"`
+	MyMaCards       []*MyMaCard            `protobuf:"bytes,3,rep,name=myMaCards,proto3" json:"myMaCards,omitempty"`
+	RestCards       []int32                `protobuf:"varint,4,rep,packed,name=restCards,proto3" json:"restCards,omitempty"`
+	WinChairIDArray []int32                `protobuf:"varint,5,rep,packed,name=winChairIDArray,proto3" json:"winChairIDArray,omitempty"`
+	GangChairID     int32                  `protobuf:"varint,6,opt,name=gangChairID,proto3" json:"gangChairID,omitempty"`
+	FangGangArray   []int32                `protobuf:"varint,7,rep,packed,name=fangGangArray,proto3" json:"fangGangArray,omitempty"`
+	HuType          int32                  `protobuf:"varint,8,opt,name=huType,proto3" json:"huType,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GameResult) Reset() {
+	*x = GameResult{}
+	mi := &file_mj_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResult) ProtoMessage() {}
+
+func (x *GameResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResult.ProtoReflect.Descriptor instead.
+func (*GameResult) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GameResult) GetScores() []int32 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *GameResult) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *GameResult) GetMyMaCards() []*MyMaCard {
+	if x != nil {
+		return x.MyMaCards
+	}
+	return nil
+}
+
+func (x *GameResult) GetRestCards() []int32 {
+	if x != nil {
+		return x.RestCards
+	}
+	return nil
+}
+
+func (x *GameResult) GetWinChairIDArray() []int32 {
+	if x != nil {
+		return x.WinChairIDArray
+	}
+	return nil
+}
+
+func (x *GameResult) GetGangChairID() int32 {
+	if x != nil {
+		return x.GangChairID
+	}
+	return 0
+}
+
+func (x *GameResult) GetFangGangArray() []int32 {
+	if x != nil {
+		return x.FangGangArray
+	}
+	return nil
+}
+
+func (x *GameResult) GetHuType() int32 {
+	if x != nil {
+		return x.HuType
+	}
+	return 0
+}
+
+type GameResultData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *GameResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameResultData) Reset() {
+	*x = GameResultData{}
+	mi := &file_mj_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResultData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResultData) ProtoMessage() {}
+
+func (x *GameResultData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResultData.ProtoReflect.Descriptor instead.
+func (*GameResultData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GameResultData) GetResult() *GameResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// GameData 断线重连、进入房间时的场景数据
+type GameData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BankerChairID  int32                  `protobuf:"varint,1,opt,name=bankerChairID,proto3" json:"bankerChairID,omitempty"`          //庄家
+	ChairCount     int32                  `protobuf:"varint,2,opt,name=chairCount,proto3" json:"chairCount,omitempty"`                //总座次人数
+	CurBureau      int32                  `protobuf:"varint,3,opt,name=curBureau,proto3" json:"curBureau,omitempty"`                  //当前局数
+	GameStatus     int32                  `protobuf:"varint,4,opt,name=gameStatus,proto3" json:"gameStatus,omitempty"`                //游戏状态
+	GameStarted    bool                   `protobuf:"varint,5,opt,name=gameStarted,proto3" json:"gameStarted,omitempty"`              //是否已经开始
+	Tick           int32                  `protobuf:"varint,6,opt,name=tick,proto3" json:"tick,omitempty"`                            //倒计时
+	MaxBureau      int32                  `protobuf:"varint,7,opt,name=maxBureau,proto3" json:"maxBureau,omitempty"`                  //最大局数
+	CurChairID     int32                  `protobuf:"varint,8,opt,name=curChairID,proto3" json:"curChairID,omitempty"`                //当前玩家
+	UserTrustArray []int32                `protobuf:"varint,9,rep,packed,name=userTrustArray,proto3" json:"userTrustArray,omitempty"` //托管
+	HandCards      []*roompb.IntList      `protobuf:"bytes,10,rep,name=handCards,proto3" json:"handCards,omitempty"`                  //手牌
+	OperateArrays  []*roompb.IntList      `protobuf:"bytes,11,rep,name=operateArrays,proto3" json:"operateArrays,omitempty"`          //操作
+	OperateRecord  []*OperateRecord       `protobuf:"bytes,12,rep,name=operateRecord,proto3" json:"operateRecord,omitempty"`          //操作记录
+	RestCardsCount int32                  `protobuf:"varint,13,opt,name=restCardsCount,proto3" json:"restCardsCount,omitempty"`       //剩余牌数
+	Result         *GameResult            `protobuf:"bytes,14,opt,name=result,proto3" json:"result,omitempty"`                        //结算
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GameData) Reset() {
+	*x = GameData{}
+	mi := &file_mj_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameData) ProtoMessage() {}
+
+func (x *GameData) ProtoReflect() protoreflect.Message {
+	mi := &file_mj_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameData.ProtoReflect.Descriptor instead.
+func (*GameData) Descriptor() ([]byte, []int) {
+	return file_mj_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GameData) GetBankerChairID() int32 {
+	if x != nil {
+		return x.BankerChairID
+	}
+	return 0
+}
+
+func (x *GameData) GetChairCount() int32 {
+	if x != nil {
+		return x.ChairCount
+	}
+	return 0
+}
+
+func (x *GameData) GetCurBureau() int32 {
+	if x != nil {
+		return x.CurBureau
+	}
+	return 0
+}
+
+func (x *GameData) GetGameStatus() int32 {
+	if x != nil {
+		return x.GameStatus
+	}
+	return 0
+}
+
+func (x *GameData) GetGameStarted() bool {
+	if x != nil {
+		return x.GameStarted
+	}
+	return false
+}
+
+func (x *GameData) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameData) GetMaxBureau() int32 {
+	if x != nil {
+		return x.MaxBureau
+	}
+	return 0
+}
+
+func (x *GameData) GetCurChairID() int32 {
+	if x != nil {
+		return x.CurChairID
+	}
+	return 0
+}
+
+func (x *GameData) GetUserTrustArray() []int32 {
+	if x != nil {
+		return x.UserTrustArray
+	}
+	return nil
+}
+
+func (x *GameData) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *GameData) GetOperateArrays() []*roompb.IntList {
+	if x != nil {
+		return x.OperateArrays
+	}
+	return nil
+}
+
+func (x *GameData) GetOperateRecord() []*OperateRecord {
+	if x != nil {
+		return x.OperateRecord
+	}
+	return nil
+}
+
+func (x *GameData) GetRestCardsCount() int32 {
+	if x != nil {
+		return x.RestCardsCount
+	}
+	return 0
+}
+
+func (x *GameData) GetResult() *GameResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_mj_proto protoreflect.FileDescriptor
+
+const file_mj_proto_rawDesc = "" +
+	"\n" +
+	"\bmj.proto\x12\x02mj\x1a\n" +
+	"room.proto\"D\n" +
+	"\x0eGameStatusData\x12\x1e\n" +
+	"\n" +
+	"gameStatus\x18\x01 \x01(\x05R\n" +
+	"gameStatus\x12\x12\n" +
+	"\x04tick\x18\x02 \x01(\x05R\x04tick\"6\n" +
+	"\x0eGameBankerData\x12$\n" +
+	"\rbankerChairID\x18\x01 \x01(\x05R\rbankerChairID\";\n" +
+	"\rGameDicesData\x12\x14\n" +
+	"\x05dice1\x18\x01 \x01(\x05R\x05dice1\x12\x14\n" +
+	"\x05dice2\x18\x02 \x01(\x05R\x05dice2\"Z\n" +
+	"\x11GameSendCardsData\x12+\n" +
+	"\thandCards\x18\x01 \x03(\v2\r.room.IntListR\thandCards\x12\x18\n" +
+	"\achairID\x18\x02 \x01(\x05R\achairID\"@\n" +
+	"\x16GameRestCardsCountData\x12&\n" +
+	"\x0erestCardsCount\x18\x01 \x01(\x05R\x0erestCardsCount\".\n" +
+	"\x0eGameBureauData\x12\x1c\n" +
+	"\tCurBureau\x18\x01 \x01(\x05R\tCurBureau\"\x82\x01\n" +
+	"\fGameTurnData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x17\n" +
+	"\x04card\x18\x02 \x01(\x05H\x00R\x04card\x88\x01\x01\x12\x12\n" +
+	"\x04tick\x18\x03 \x01(\x05R\x04tick\x12\"\n" +
+	"\foperateArray\x18\x04 \x03(\x05R\foperateArrayB\a\n" +
+	"\x05_card\"p\n" +
+	"\fGameChatData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\x05R\x04type\x12\x10\n" +
+	"\x03msg\x18\x03 \x01(\tR\x03msg\x12 \n" +
+	"\vrecipientID\x18\x04 \x01(\x05R\vrecipientID\"w\n" +
+	"\x13GameTurnOperateData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x12\n" +
+	"\x04card\x18\x02 \x01(\x05R\x04card\x12\x18\n" +
+	"\aoperate\x18\x03 \x01(\x05R\aoperate\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\"0\n" +
+	"\bMyMaCard\x12\x12\n" +
+	"\x04card\x18\x01 \x01(\x05R\x04card\x12\x10\n" +
+	"\x03win\x18\x02 \x01(\bR\x03win\"W\n" +
+	"\rOperateRecord\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x12\n" +
+	"\x04card\x18\x02 \x01(\x05R\x04card\x12\x18\n" +
+	"\aoperate\x18\x03 \x01(\x05R\aoperate\"\xa5\x02\n" +
+	"\n" +
+	"GameResult\x12\x16\n" +
+	"\x06scores\x18\x01 \x03(\x05R\x06scores\x12+\n" +
+	"\thandCards\x18\x02 \x03(\v2\r.room.IntListR\thandCards\x12*\n" +
+	"\tmyMaCards\x18\x03 \x03(\v2\f.mj.MyMaCardR\tmyMaCards\x12\x1c\n" +
+	"\trestCards\x18\x04 \x03(\x05R\trestCards\x12(\n" +
+	"\x0fwinChairIDArray\x18\x05 \x03(\x05R\x0fwinChairIDArray\x12 \n" +
+	"\vgangChairID\x18\x06 \x01(\x05R\vgangChairID\x12$\n" +
+	"\rfangGangArray\x18\a \x03(\x05R\rfangGangArray\x12\x16\n" +
+	"\x06huType\x18\b \x01(\x05R\x06huType\"8\n" +
+	"\x0eGameResultData\x12&\n" +
+	"\x06result\x18\x01 \x01(\v2\x0e.mj.GameResultR\x06result\"\x95\x04\n" +
+	"\bGameData\x12$\n" +
+	"\rbankerChairID\x18\x01 \x01(\x05R\rbankerChairID\x12\x1e\n" +
+	"\n" +
+	"chairCount\x18\x02 \x01(\x05R\n" +
+	"chairCount\x12\x1c\n" +
+	"\tcurBureau\x18\x03 \x01(\x05R\tcurBureau\x12\x1e\n" +
+	"\n" +
+	"gameStatus\x18\x04 \x01(\x05R\n" +
+	"gameStatus\x12 \n" +
+	"\vgameStarted\x18\x05 \x01(\bR\vgameStarted\x12\x12\n" +
+	"\x04tick\x18\x06 \x01(\x05R\x04tick\x12\x1c\n" +
+	"\tmaxBureau\x18\a \x01(\x05R\tmaxBureau\x12\x1e\n" +
+	"\n" +
+	"curChairID\x18\b \x01(\x05R\n" +
+	"curChairID\x12&\n" +
+	"\x0euserTrustArray\x18\t \x03(\x05R\x0euserTrustArray\x12+\n" +
+	"\thandCards\x18\n" +
+	" \x03(\v2\r.room.IntListR\thandCards\x123\n" +
+	"\roperateArrays\x18\v \x03(\v2\r.room.IntListR\roperateArrays\x127\n" +
+	"\roperateRecord\x18\f \x03(\v2\x11.mj.OperateRecordR\roperateRecord\x12&\n" +
+	"\x0erestCardsCount\x18\r \x01(\x05R\x0erestCardsCount\x12&\n" +
+	"\x06result\x18\x0e \x01(\v2\x0e.mj.GameResultR\x06resultB\x13Z\x11game/pb/mjpb;mjpbb\x06proto3"
+
+var (
+	file_mj_proto_rawDescOnce sync.Once
+	file_mj_proto_rawDescData []byte
+)
+
+func file_mj_proto_rawDescGZIP() []byte {
+	file_mj_proto_rawDescOnce.Do(func() {
+		file_mj_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mj_proto_rawDesc), len(file_mj_proto_rawDesc)))
+	})
+	return file_mj_proto_rawDescData
+}
+
+var file_mj_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_mj_proto_goTypes = []any{
+	(*GameStatusData)(nil),         // 0: mj.GameStatusData
+	(*GameBankerData)(nil),         // 1: mj.GameBankerData
+	(*GameDicesData)(nil),          // 2: mj.GameDicesData
+	(*GameSendCardsData)(nil),      // 3: mj.GameSendCardsData
+	(*GameRestCardsCountData)(nil), // 4: mj.GameRestCardsCountData
+	(*GameBureauData)(nil),         // 5: mj.GameBureauData
+	(*GameTurnData)(nil),           // 6: mj.GameTurnData
+	(*GameChatData)(nil),           // 7: mj.GameChatData
+	(*GameTurnOperateData)(nil),    // 8: mj.GameTurnOperateData
+	(*MyMaCard)(nil),               // 9: mj.MyMaCard
+	(*OperateRecord)(nil),          // 10: mj.OperateRecord
+	(*GameResult)(nil),             // 11: mj.GameResult
+	(*GameResultData)(nil),         // 12: mj.GameResultData
+	(*GameData)(nil),               // 13: mj.GameData
+	(*roompb.IntList)(nil),         // 14: room.IntList
+}
+var file_mj_proto_depIdxs = []int32{
+	14, // 0: mj.GameSendCardsData.handCards:type_name -> room.IntList
+	14, // 1: mj.GameResult.handCards:type_name -> room.IntList
+	9,  // 2: mj.GameResult.myMaCards:type_name -> mj.MyMaCard
+	11, // 3: mj.GameResultData.result:type_name -> mj.GameResult
+	14, // 4: mj.GameData.handCards:type_name -> room.IntList
+	14, // 5: mj.GameData.operateArrays:type_name -> room.IntList
+	10, // 6: mj.GameData.operateRecord:type_name -> mj.OperateRecord
+	11, // 7: mj.GameData.result:type_name -> mj.GameResult
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_mj_proto_init() }
+func file_mj_proto_init() {
+	if File_mj_proto != nil {
+		return
+	}
+	file_mj_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mj_proto_rawDesc), len(file_mj_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_mj_proto_goTypes,
+		DependencyIndexes: file_mj_proto_depIdxs,
+		MessageInfos:      file_mj_proto_msgTypes,
+	}.Build()
+	File_mj_proto = out.File
+	file_mj_proto_goTypes = nil
+	file_mj_proto_depIdxs = nil
+}
@@ -0,0 +1,1124 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.3
+// source: room.proto
+
+package roompb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// IntList 二维数组的一行（手牌、操作等），json、msgpack 中直接编码为数组
+type IntList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int32                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntList) Reset() {
+	*x = IntList{}
+	mi := &file_room_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntList) ProtoMessage() {}
+
+func (x *IntList) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntList.ProtoReflect.Descriptor instead.
+func (*IntList) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IntList) GetValues() []int32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UserInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Avatar        string                 `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Gold          int64                  `protobuf:"varint,4,opt,name=gold,proto3" json:"gold,omitempty"`
+	FrontendId    string                 `protobuf:"bytes,5,opt,name=frontendId,proto3" json:"frontendId,omitempty"`
+	Address       string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Location      string                 `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	LastLoginIP   string                 `protobuf:"bytes,8,opt,name=lastLoginIP,proto3" json:"lastLoginIP,omitempty"`
+	Sex           int32                  `protobuf:"varint,9,opt,name=sex,proto3" json:"sex,omitempty"`
+	Score         int32                  `protobuf:"varint,10,opt,name=score,proto3" json:"score,omitempty"`
+	SpreaderID    string                 `protobuf:"bytes,11,opt,name=spreaderID,proto3" json:"spreaderID,omitempty"` //推广ID
+	ProhibitGame  bool                   `protobuf:"varint,12,opt,name=prohibitGame,proto3" json:"prohibitGame,omitempty"`
+	RoomID        string                 `protobuf:"bytes,13,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserInfo) Reset() {
+	*x = UserInfo{}
+	mi := &file_room_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInfo) ProtoMessage() {}
+
+func (x *UserInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInfo.ProtoReflect.Descriptor instead.
+func (*UserInfo) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserInfo) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UserInfo) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *UserInfo) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *UserInfo) GetGold() int64 {
+	if x != nil {
+		return x.Gold
+	}
+	return 0
+}
+
+func (x *UserInfo) GetFrontendId() string {
+	if x != nil {
+		return x.FrontendId
+	}
+	return ""
+}
+
+func (x *UserInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UserInfo) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UserInfo) GetLastLoginIP() string {
+	if x != nil {
+		return x.LastLoginIP
+	}
+	return ""
+}
+
+func (x *UserInfo) GetSex() int32 {
+	if x != nil {
+		return x.Sex
+	}
+	return 0
+}
+
+func (x *UserInfo) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *UserInfo) GetSpreaderID() string {
+	if x != nil {
+		return x.SpreaderID
+	}
+	return ""
+}
+
+func (x *UserInfo) GetProhibitGame() bool {
+	if x != nil {
+		return x.ProhibitGame
+	}
+	return false
+}
+
+func (x *UserInfo) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+type RoomUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserInfo      *UserInfo              `protobuf:"bytes,1,opt,name=userInfo,proto3" json:"userInfo,omitempty"`
+	ChairID       int32                  `protobuf:"varint,2,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	UserStatus    int32                  `protobuf:"varint,3,opt,name=userStatus,proto3" json:"userStatus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomUser) Reset() {
+	*x = RoomUser{}
+	mi := &file_room_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomUser) ProtoMessage() {}
+
+func (x *RoomUser) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomUser.ProtoReflect.Descriptor instead.
+func (*RoomUser) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RoomUser) GetUserInfo() *UserInfo {
+	if x != nil {
+		return x.UserInfo
+	}
+	return nil
+}
+
+func (x *RoomUser) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *RoomUser) GetUserStatus() int32 {
+	if x != nil {
+		return x.UserStatus
+	}
+	return 0
+}
+
+type RoomCreator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	CreatorType   int32                  `protobuf:"varint,2,opt,name=creatorType,proto3" json:"creatorType,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomCreator) Reset() {
+	*x = RoomCreator{}
+	mi := &file_room_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomCreator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomCreator) ProtoMessage() {}
+
+func (x *RoomCreator) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomCreator.ProtoReflect.Descriptor instead.
+func (*RoomCreator) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RoomCreator) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *RoomCreator) GetCreatorType() int32 {
+	if x != nil {
+		return x.CreatorType
+	}
+	return 0
+}
+
+type GameRule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AddScores      []int32                `protobuf:"varint,1,rep,packed,name=addScores,proto3" json:"addScores,omitempty"`     //加注分
+	BaseScore      int32                  `protobuf:"varint,2,opt,name=baseScore,proto3" json:"baseScore,omitempty"`            //底分
+	Bureau         int32                  `protobuf:"varint,3,opt,name=bureau,proto3" json:"bureau,omitempty"`                  //局数
+	CanEnter       bool                   `protobuf:"varint,4,opt,name=canEnter,proto3" json:"canEnter,omitempty"`              //中途进人
+	CanTrust       bool                   `protobuf:"varint,5,opt,name=canTrust,proto3" json:"canTrust,omitempty"`              //允许托管
+	Chunniunai     bool                   `protobuf:"varint,6,opt,name=chunniunai,proto3" json:"chunniunai,omitempty"`          //是否允许搓牛
+	CanWatch       bool                   `protobuf:"varint,7,opt,name=canWatch,proto3" json:"canWatch,omitempty"`              //允许观战
+	Cuopai         bool                   `protobuf:"varint,8,opt,name=cuopai,proto3" json:"cuopai,omitempty"`                  //是否允许搓牌
+	GameFrameType  int32                  `protobuf:"varint,9,opt,name=gameFrameType,proto3" json:"gameFrameType,omitempty"`    //游戏模式
+	GameType       int32                  `protobuf:"varint,10,opt,name=gameType,proto3" json:"gameType,omitempty"`             //游戏类型
+	Ma             int32                  `protobuf:"varint,11,opt,name=ma,proto3" json:"ma,omitempty"`                         //扎码
+	MaxPlayerCount int32                  `protobuf:"varint,12,opt,name=maxPlayerCount,proto3" json:"maxPlayerCount,omitempty"` //最大人数
+	MinPlayerCount int32                  `protobuf:"varint,13,opt,name=minPlayerCount,proto3" json:"minPlayerCount,omitempty"` //最小人数
+	PayDiamond     int32                  `protobuf:"varint,14,opt,name=payDiamond,proto3" json:"payDiamond,omitempty"`         //房费
+	PayType        int32                  `protobuf:"varint,15,opt,name=payType,proto3" json:"payType,omitempty"`               //支付方式 1 AA支付 2 赢家支付 3 我支付
+	Qidui          bool                   `protobuf:"varint,16,opt,name=qidui,proto3" json:"qidui,omitempty"`                   //七对
+	RoomType       int32                  `protobuf:"varint,17,opt,name=roomType,proto3" json:"roomType,omitempty"`             //1 正常房间 2 持续房间 3 百人房间
+	Yuyin          bool                   `protobuf:"varint,18,opt,name=yuyin,proto3" json:"yuyin,omitempty"`                   //语音
+	TrustTm        int32                  `protobuf:"varint,19,opt,name=trustTm,proto3" json:"trustTm,omitempty"`               //托管时长
+	Fangzuobi      bool                   `protobuf:"varint,20,opt,name=fangzuobi,proto3" json:"fangzuobi,omitempty"`           //防作弊
+	MaxScore       int32                  `protobuf:"varint,21,opt,name=maxScore,proto3" json:"maxScore,omitempty"`             //最大加注分
+	RoundType      int32                  `protobuf:"varint,22,opt,name=roundType,proto3" json:"roundType,omitempty"`           //轮数
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GameRule) Reset() {
+	*x = GameRule{}
+	mi := &file_room_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameRule) ProtoMessage() {}
+
+func (x *GameRule) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameRule.ProtoReflect.Descriptor instead.
+func (*GameRule) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GameRule) GetAddScores() []int32 {
+	if x != nil {
+		return x.AddScores
+	}
+	return nil
+}
+
+func (x *GameRule) GetBaseScore() int32 {
+	if x != nil {
+		return x.BaseScore
+	}
+	return 0
+}
+
+func (x *GameRule) GetBureau() int32 {
+	if x != nil {
+		return x.Bureau
+	}
+	return 0
+}
+
+func (x *GameRule) GetCanEnter() bool {
+	if x != nil {
+		return x.CanEnter
+	}
+	return false
+}
+
+func (x *GameRule) GetCanTrust() bool {
+	if x != nil {
+		return x.CanTrust
+	}
+	return false
+}
+
+func (x *GameRule) GetChunniunai() bool {
+	if x != nil {
+		return x.Chunniunai
+	}
+	return false
+}
+
+func (x *GameRule) GetCanWatch() bool {
+	if x != nil {
+		return x.CanWatch
+	}
+	return false
+}
+
+func (x *GameRule) GetCuopai() bool {
+	if x != nil {
+		return x.Cuopai
+	}
+	return false
+}
+
+func (x *GameRule) GetGameFrameType() int32 {
+	if x != nil {
+		return x.GameFrameType
+	}
+	return 0
+}
+
+func (x *GameRule) GetGameType() int32 {
+	if x != nil {
+		return x.GameType
+	}
+	return 0
+}
+
+func (x *GameRule) GetMa() int32 {
+	if x != nil {
+		return x.Ma
+	}
+	return 0
+}
+
+func (x *GameRule) GetMaxPlayerCount() int32 {
+	if x != nil {
+		return x.MaxPlayerCount
+	}
+	return 0
+}
+
+func (x *GameRule) GetMinPlayerCount() int32 {
+	if x != nil {
+		return x.MinPlayerCount
+	}
+	return 0
+}
+
+func (x *GameRule) GetPayDiamond() int32 {
+	if x != nil {
+		return x.PayDiamond
+	}
+	return 0
+}
+
+func (x *GameRule) GetPayType() int32 {
+	if x != nil {
+		return x.PayType
+	}
+	return 0
+}
+
+func (x *GameRule) GetQidui() bool {
+	if x != nil {
+		return x.Qidui
+	}
+	return false
+}
+
+func (x *GameRule) GetRoomType() int32 {
+	if x != nil {
+		return x.RoomType
+	}
+	return 0
+}
+
+func (x *GameRule) GetYuyin() bool {
+	if x != nil {
+		return x.Yuyin
+	}
+	return false
+}
+
+func (x *GameRule) GetTrustTm() int32 {
+	if x != nil {
+		return x.TrustTm
+	}
+	return 0
+}
+
+func (x *GameRule) GetFangzuobi() bool {
+	if x != nil {
+		return x.Fangzuobi
+	}
+	return false
+}
+
+func (x *GameRule) GetMaxScore() int32 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+func (x *GameRule) GetRoundType() int32 {
+	if x != nil {
+		return x.RoundType
+	}
+	return 0
+}
+
+// MessagePush RoomMessagePush、GameMessagePush 的外层，type 区分 data 中的具体消息
+type MessagePush struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Data          *anypb.Any             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	PushRouter    string                 `protobuf:"bytes,3,opt,name=pushRouter,proto3" json:"pushRouter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessagePush) Reset() {
+	*x = MessagePush{}
+	mi := &file_room_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePush) ProtoMessage() {}
+
+func (x *MessagePush) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePush.ProtoReflect.Descriptor instead.
+func (*MessagePush) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MessagePush) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MessagePush) GetData() *anypb.Any {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *MessagePush) GetPushRouter() string {
+	if x != nil {
+		return x.PushRouter
+	}
+	return ""
+}
+
+type UpdateUserInfoPush struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	PushRouter    string                 `protobuf:"bytes,2,opt,name=pushRouter,proto3" json:"pushRouter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserInfoPush) Reset() {
+	*x = UpdateUserInfoPush{}
+	mi := &file_room_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserInfoPush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserInfoPush) ProtoMessage() {}
+
+func (x *UpdateUserInfoPush) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserInfoPush.ProtoReflect.Descriptor instead.
+func (*UpdateUserInfoPush) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateUserInfoPush) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *UpdateUserInfoPush) GetPushRouter() string {
+	if x != nil {
+		return x.PushRouter
+	}
+	return ""
+}
+
+type SelfEntryRoomPush struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameType      int32                  `protobuf:"varint,1,opt,name=gameType,proto3" json:"gameType,omitempty"`
+	PushRouter    string                 `protobuf:"bytes,2,opt,name=pushRouter,proto3" json:"pushRouter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfEntryRoomPush) Reset() {
+	*x = SelfEntryRoomPush{}
+	mi := &file_room_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfEntryRoomPush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfEntryRoomPush) ProtoMessage() {}
+
+func (x *SelfEntryRoomPush) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfEntryRoomPush.ProtoReflect.Descriptor instead.
+func (*SelfEntryRoomPush) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SelfEntryRoomPush) GetGameType() int32 {
+	if x != nil {
+		return x.GameType
+	}
+	return 0
+}
+
+func (x *SelfEntryRoomPush) GetPushRouter() string {
+	if x != nil {
+		return x.PushRouter
+	}
+	return ""
+}
+
+// UserEntryData 用户进入、离开房间
+type UserEntryData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomUserInfo  *RoomUser              `protobuf:"bytes,1,opt,name=roomUserInfo,proto3" json:"roomUserInfo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEntryData) Reset() {
+	*x = UserEntryData{}
+	mi := &file_room_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEntryData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEntryData) ProtoMessage() {}
+
+func (x *UserEntryData) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEntryData.ProtoReflect.Descriptor instead.
+func (*UserEntryData) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UserEntryData) GetRoomUserInfo() *RoomUser {
+	if x != nil {
+		return x.RoomUserInfo
+	}
+	return nil
+}
+
+type UserReadyData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReadyData) Reset() {
+	*x = UserReadyData{}
+	mi := &file_room_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReadyData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReadyData) ProtoMessage() {}
+
+func (x *UserReadyData) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReadyData.ProtoReflect.Descriptor instead.
+func (*UserReadyData) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UserReadyData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+type DismissData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NameArr       []string               `protobuf:"bytes,1,rep,name=nameArr,proto3" json:"nameArr,omitempty"`
+	ChairIDArr    []bool                 `protobuf:"varint,2,rep,packed,name=chairIDArr,proto3" json:"chairIDArr,omitempty"` //同意解散的座位为 true
+	AvatarArr     []string               `protobuf:"bytes,3,rep,name=avatarArr,proto3" json:"avatarArr,omitempty"`
+	OnlineArr     []bool                 `protobuf:"varint,4,rep,packed,name=onlineArr,proto3" json:"onlineArr,omitempty"`
+	AskChairId    int32                  `protobuf:"varint,5,opt,name=askChairId,proto3" json:"askChairId,omitempty"`
+	Tm            int32                  `protobuf:"varint,6,opt,name=tm,proto3" json:"tm,omitempty"` //倒计时
+	ScoreArr      []int32                `protobuf:"varint,7,rep,packed,name=scoreArr,proto3" json:"scoreArr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DismissData) Reset() {
+	*x = DismissData{}
+	mi := &file_room_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DismissData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DismissData) ProtoMessage() {}
+
+func (x *DismissData) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DismissData.ProtoReflect.Descriptor instead.
+func (*DismissData) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DismissData) GetNameArr() []string {
+	if x != nil {
+		return x.NameArr
+	}
+	return nil
+}
+
+func (x *DismissData) GetChairIDArr() []bool {
+	if x != nil {
+		return x.ChairIDArr
+	}
+	return nil
+}
+
+func (x *DismissData) GetAvatarArr() []string {
+	if x != nil {
+		return x.AvatarArr
+	}
+	return nil
+}
+
+func (x *DismissData) GetOnlineArr() []bool {
+	if x != nil {
+		return x.OnlineArr
+	}
+	return nil
+}
+
+func (x *DismissData) GetAskChairId() int32 {
+	if x != nil {
+		return x.AskChairId
+	}
+	return 0
+}
+
+func (x *DismissData) GetTm() int32 {
+	if x != nil {
+		return x.Tm
+	}
+	return 0
+}
+
+func (x *DismissData) GetScoreArr() []int32 {
+	if x != nil {
+		return x.ScoreArr
+	}
+	return nil
+}
+
+type RoomSceneData struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomID          string                 `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	RoomCreatorInfo *RoomCreator           `protobuf:"bytes,2,opt,name=roomCreatorInfo,proto3" json:"roomCreatorInfo,omitempty"`
+	GameRule        *GameRule              `protobuf:"bytes,3,opt,name=gameRule,proto3" json:"gameRule,omitempty"`
+	RoomUserInfoArr []*RoomUser            `protobuf:"bytes,4,rep,name=roomUserInfoArr,proto3" json:"roomUserInfoArr,omitempty"`
+	GameData        *anypb.Any             `protobuf:"bytes,5,opt,name=gameData,proto3" json:"gameData,omitempty"` //各个游戏的场景数据
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoomSceneData) Reset() {
+	*x = RoomSceneData{}
+	mi := &file_room_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomSceneData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomSceneData) ProtoMessage() {}
+
+func (x *RoomSceneData) ProtoReflect() protoreflect.Message {
+	mi := &file_room_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomSceneData.ProtoReflect.Descriptor instead.
+func (*RoomSceneData) Descriptor() ([]byte, []int) {
+	return file_room_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RoomSceneData) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *RoomSceneData) GetRoomCreatorInfo() *RoomCreator {
+	if x != nil {
+		return x.RoomCreatorInfo
+	}
+	return nil
+}
+
+func (x *RoomSceneData) GetGameRule() *GameRule {
+	if x != nil {
+		return x.GameRule
+	}
+	return nil
+}
+
+func (x *RoomSceneData) GetRoomUserInfoArr() []*RoomUser {
+	if x != nil {
+		return x.RoomUserInfoArr
+	}
+	return nil
+}
+
+func (x *RoomSceneData) GetGameData() *anypb.Any {
+	if x != nil {
+		return x.GameData
+	}
+	return nil
+}
+
+var File_room_proto protoreflect.FileDescriptor
+
+const file_room_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"room.proto\x12\x04room\x1a\x19google/protobuf/any.proto\"!\n" +
+	"\aIntList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x05R\x06values\"\xe0\x02\n" +
+	"\bUserInfo\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x12\n" +
+	"\x04gold\x18\x04 \x01(\x03R\x04gold\x12\x1e\n" +
+	"\n" +
+	"frontendId\x18\x05 \x01(\tR\n" +
+	"frontendId\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x1a\n" +
+	"\blocation\x18\a \x01(\tR\blocation\x12 \n" +
+	"\vlastLoginIP\x18\b \x01(\tR\vlastLoginIP\x12\x10\n" +
+	"\x03sex\x18\t \x01(\x05R\x03sex\x12\x14\n" +
+	"\x05score\x18\n" +
+	" \x01(\x05R\x05score\x12\x1e\n" +
+	"\n" +
+	"spreaderID\x18\v \x01(\tR\n" +
+	"spreaderID\x12\"\n" +
+	"\fprohibitGame\x18\f \x01(\bR\fprohibitGame\x12\x16\n" +
+	"\x06roomID\x18\r \x01(\tR\x06roomID\"p\n" +
+	"\bRoomUser\x12*\n" +
+	"\buserInfo\x18\x01 \x01(\v2\x0e.room.UserInfoR\buserInfo\x12\x18\n" +
+	"\achairID\x18\x02 \x01(\x05R\achairID\x12\x1e\n" +
+	"\n" +
+	"userStatus\x18\x03 \x01(\x05R\n" +
+	"userStatus\"A\n" +
+	"\vRoomCreator\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12 \n" +
+	"\vcreatorType\x18\x02 \x01(\x05R\vcreatorType\"\x80\x05\n" +
+	"\bGameRule\x12\x1c\n" +
+	"\taddScores\x18\x01 \x03(\x05R\taddScores\x12\x1c\n" +
+	"\tbaseScore\x18\x02 \x01(\x05R\tbaseScore\x12\x16\n" +
+	"\x06bureau\x18\x03 \x01(\x05R\x06bureau\x12\x1a\n" +
+	"\bcanEnter\x18\x04 \x01(\bR\bcanEnter\x12\x1a\n" +
+	"\bcanTrust\x18\x05 \x01(\bR\bcanTrust\x12\x1e\n" +
+	"\n" +
+	"chunniunai\x18\x06 \x01(\bR\n" +
+	"chunniunai\x12\x1a\n" +
+	"\bcanWatch\x18\a \x01(\bR\bcanWatch\x12\x16\n" +
+	"\x06cuopai\x18\b \x01(\bR\x06cuopai\x12$\n" +
+	"\rgameFrameType\x18\t \x01(\x05R\rgameFrameType\x12\x1a\n" +
+	"\bgameType\x18\n" +
+	" \x01(\x05R\bgameType\x12\x0e\n" +
+	"\x02ma\x18\v \x01(\x05R\x02ma\x12&\n" +
+	"\x0emaxPlayerCount\x18\f \x01(\x05R\x0emaxPlayerCount\x12&\n" +
+	"\x0eminPlayerCount\x18\r \x01(\x05R\x0eminPlayerCount\x12\x1e\n" +
+	"\n" +
+	"payDiamond\x18\x0e \x01(\x05R\n" +
+	"payDiamond\x12\x18\n" +
+	"\apayType\x18\x0f \x01(\x05R\apayType\x12\x14\n" +
+	"\x05qidui\x18\x10 \x01(\bR\x05qidui\x12\x1a\n" +
+	"\broomType\x18\x11 \x01(\x05R\broomType\x12\x14\n" +
+	"\x05yuyin\x18\x12 \x01(\bR\x05yuyin\x12\x18\n" +
+	"\atrustTm\x18\x13 \x01(\x05R\atrustTm\x12\x1c\n" +
+	"\tfangzuobi\x18\x14 \x01(\bR\tfangzuobi\x12\x1a\n" +
+	"\bmaxScore\x18\x15 \x01(\x05R\bmaxScore\x12\x1c\n" +
+	"\troundType\x18\x16 \x01(\x05R\troundType\"k\n" +
+	"\vMessagePush\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12(\n" +
+	"\x04data\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x04data\x12\x1e\n" +
+	"\n" +
+	"pushRouter\x18\x03 \x01(\tR\n" +
+	"pushRouter\"L\n" +
+	"\x12UpdateUserInfoPush\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\tR\x06roomId\x12\x1e\n" +
+	"\n" +
+	"pushRouter\x18\x02 \x01(\tR\n" +
+	"pushRouter\"O\n" +
+	"\x11SelfEntryRoomPush\x12\x1a\n" +
+	"\bgameType\x18\x01 \x01(\x05R\bgameType\x12\x1e\n" +
+	"\n" +
+	"pushRouter\x18\x02 \x01(\tR\n" +
+	"pushRouter\"C\n" +
+	"\rUserEntryData\x122\n" +
+	"\froomUserInfo\x18\x01 \x01(\v2\x0e.room.RoomUserR\froomUserInfo\")\n" +
+	"\rUserReadyData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\"\xcf\x01\n" +
+	"\vDismissData\x12\x18\n" +
+	"\anameArr\x18\x01 \x03(\tR\anameArr\x12\x1e\n" +
+	"\n" +
+	"chairIDArr\x18\x02 \x03(\bR\n" +
+	"chairIDArr\x12\x1c\n" +
+	"\tavatarArr\x18\x03 \x03(\tR\tavatarArr\x12\x1c\n" +
+	"\tonlineArr\x18\x04 \x03(\bR\tonlineArr\x12\x1e\n" +
+	"\n" +
+	"askChairId\x18\x05 \x01(\x05R\n" +
+	"askChairId\x12\x0e\n" +
+	"\x02tm\x18\x06 \x01(\x05R\x02tm\x12\x1a\n" +
+	"\bscoreArr\x18\a \x03(\x05R\bscoreArr\"\xfc\x01\n" +
+	"\rRoomSceneData\x12\x16\n" +
+	"\x06roomID\x18\x01 \x01(\tR\x06roomID\x12;\n" +
+	"\x0froomCreatorInfo\x18\x02 \x01(\v2\x11.room.RoomCreatorR\x0froomCreatorInfo\x12*\n" +
+	"\bgameRule\x18\x03 \x01(\v2\x0e.room.GameRuleR\bgameRule\x128\n" +
+	"\x0froomUserInfoArr\x18\x04 \x03(\v2\x0e.room.RoomUserR\x0froomUserInfoArr\x120\n" +
+	"\bgameData\x18\x05 \x01(\v2\x14.google.protobuf.AnyR\bgameDataB\x17Z\x15game/pb/roompb;roompbb\x06proto3"
+
+var (
+	file_room_proto_rawDescOnce sync.Once
+	file_room_proto_rawDescData []byte
+)
+
+func file_room_proto_rawDescGZIP() []byte {
+	file_room_proto_rawDescOnce.Do(func() {
+		file_room_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_room_proto_rawDesc), len(file_room_proto_rawDesc)))
+	})
+	return file_room_proto_rawDescData
+}
+
+var file_room_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_room_proto_goTypes = []any{
+	(*IntList)(nil),            // 0: room.IntList
+	(*UserInfo)(nil),           // 1: room.UserInfo
+	(*RoomUser)(nil),           // 2: room.RoomUser
+	(*RoomCreator)(nil),        // 3: room.RoomCreator
+	(*GameRule)(nil),           // 4: room.GameRule
+	(*MessagePush)(nil),        // 5: room.MessagePush
+	(*UpdateUserInfoPush)(nil), // 6: room.UpdateUserInfoPush
+	(*SelfEntryRoomPush)(nil),  // 7: room.SelfEntryRoomPush
+	(*UserEntryData)(nil),      // 8: room.UserEntryData
+	(*UserReadyData)(nil),      // 9: room.UserReadyData
+	(*DismissData)(nil),        // 10: room.DismissData
+	(*RoomSceneData)(nil),      // 11: room.RoomSceneData
+	(*anypb.Any)(nil),          // 12: google.protobuf.Any
+}
+var file_room_proto_depIdxs = []int32{
+	1,  // 0: room.RoomUser.userInfo:type_name -> room.UserInfo
+	12, // 1: room.MessagePush.data:type_name -> google.protobuf.Any
+	2,  // 2: room.UserEntryData.roomUserInfo:type_name -> room.RoomUser
+	3,  // 3: room.RoomSceneData.roomCreatorInfo:type_name -> room.RoomCreator
+	4,  // 4: room.RoomSceneData.gameRule:type_name -> room.GameRule
+	2,  // 5: room.RoomSceneData.roomUserInfoArr:type_name -> room.RoomUser
+	12, // 6: room.RoomSceneData.gameData:type_name -> google.protobuf.Any
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_room_proto_init() }
+func file_room_proto_init() {
+	if File_room_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_room_proto_rawDesc), len(file_room_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_room_proto_goTypes,
+		DependencyIndexes: file_room_proto_depIdxs,
+		MessageInfos:      file_room_proto_msgTypes,
+	}.Build()
+	File_room_proto = out.File
+	file_room_proto_goTypes = nil
+	file_room_proto_depIdxs = nil
+}
@@ -0,0 +1,1317 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.3
+// source: sz.proto
+
+package szpb
+
+import (
+	roompb "game/pb/roompb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateUserInfoGoldPush struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gold          int64                  `protobuf:"varint,1,opt,name=gold,proto3" json:"gold,omitempty"`
+	PushRouter    string                 `protobuf:"bytes,2,opt,name=pushRouter,proto3" json:"pushRouter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserInfoGoldPush) Reset() {
+	*x = UpdateUserInfoGoldPush{}
+	mi := &file_sz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserInfoGoldPush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserInfoGoldPush) ProtoMessage() {}
+
+func (x *UpdateUserInfoGoldPush) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserInfoGoldPush.ProtoReflect.Descriptor instead.
+func (*UpdateUserInfoGoldPush) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateUserInfoGoldPush) GetGold() int64 {
+	if x != nil {
+		return x.Gold
+	}
+	return 0
+}
+
+func (x *UpdateUserInfoGoldPush) GetPushRouter() string {
+	if x != nil {
+		return x.PushRouter
+	}
+	return ""
+}
+
+type GameBankerData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankerChairID int32                  `protobuf:"varint,1,opt,name=bankerChairID,proto3" json:"bankerChairID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameBankerData) Reset() {
+	*x = GameBankerData{}
+	mi := &file_sz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameBankerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameBankerData) ProtoMessage() {}
+
+func (x *GameBankerData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameBankerData.ProtoReflect.Descriptor instead.
+func (*GameBankerData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GameBankerData) GetBankerChairID() int32 {
+	if x != nil {
+		return x.BankerChairID
+	}
+	return 0
+}
+
+type GameBureauData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurBureau     int32                  `protobuf:"varint,1,opt,name=curBureau,proto3" json:"curBureau,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameBureauData) Reset() {
+	*x = GameBureauData{}
+	mi := &file_sz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameBureauData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameBureauData) ProtoMessage() {}
+
+func (x *GameBureauData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameBureauData.ProtoReflect.Descriptor instead.
+func (*GameBureauData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GameBureauData) GetCurBureau() int32 {
+	if x != nil {
+		return x.CurBureau
+	}
+	return 0
+}
+
+type GameStatusData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameStatus    int32                  `protobuf:"varint,1,opt,name=gameStatus,proto3" json:"gameStatus,omitempty"`
+	Tick          int32                  `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameStatusData) Reset() {
+	*x = GameStatusData{}
+	mi := &file_sz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStatusData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStatusData) ProtoMessage() {}
+
+func (x *GameStatusData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStatusData.ProtoReflect.Descriptor instead.
+func (*GameStatusData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GameStatusData) GetGameStatus() int32 {
+	if x != nil {
+		return x.GameStatus
+	}
+	return 0
+}
+
+func (x *GameStatusData) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+type GameSendCardsData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HandCards     []*roompb.IntList      `protobuf:"bytes,1,rep,name=handCards,proto3" json:"handCards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameSendCardsData) Reset() {
+	*x = GameSendCardsData{}
+	mi := &file_sz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameSendCardsData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSendCardsData) ProtoMessage() {}
+
+func (x *GameSendCardsData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSendCardsData.ProtoReflect.Descriptor instead.
+func (*GameSendCardsData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GameSendCardsData) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+type GamePourScoreData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	ChairScore    int32                  `protobuf:"varint,3,opt,name=chairScore,proto3" json:"chairScore,omitempty"`
+	Scores        int32                  `protobuf:"varint,4,opt,name=scores,proto3" json:"scores,omitempty"`
+	Type          int32                  `protobuf:"varint,5,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GamePourScoreData) Reset() {
+	*x = GamePourScoreData{}
+	mi := &file_sz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GamePourScoreData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GamePourScoreData) ProtoMessage() {}
+
+func (x *GamePourScoreData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GamePourScoreData.ProtoReflect.Descriptor instead.
+func (*GamePourScoreData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GamePourScoreData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GamePourScoreData) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GamePourScoreData) GetChairScore() int32 {
+	if x != nil {
+		return x.ChairScore
+	}
+	return 0
+}
+
+func (x *GamePourScoreData) GetScores() int32 {
+	if x != nil {
+		return x.Scores
+	}
+	return 0
+}
+
+func (x *GamePourScoreData) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+type GameRoundData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameRoundData) Reset() {
+	*x = GameRoundData{}
+	mi := &file_sz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameRoundData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameRoundData) ProtoMessage() {}
+
+func (x *GameRoundData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameRoundData.ProtoReflect.Descriptor instead.
+func (*GameRoundData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GameRoundData) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type GameTurnData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurChairID    int32                  `protobuf:"varint,1,opt,name=curChairID,proto3" json:"curChairID,omitempty"`
+	CurScore      int32                  `protobuf:"varint,2,opt,name=curScore,proto3" json:"curScore,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameTurnData) Reset() {
+	*x = GameTurnData{}
+	mi := &file_sz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameTurnData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameTurnData) ProtoMessage() {}
+
+func (x *GameTurnData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameTurnData.ProtoReflect.Descriptor instead.
+func (*GameTurnData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameTurnData) GetCurChairID() int32 {
+	if x != nil {
+		return x.CurChairID
+	}
+	return 0
+}
+
+func (x *GameTurnData) GetCurScore() int32 {
+	if x != nil {
+		return x.CurScore
+	}
+	return 0
+}
+
+type GameLookData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	Cards         *roompb.IntList        `protobuf:"bytes,2,opt,name=cards,proto3" json:"cards,omitempty"` //没有看到牌时为 null
+	Cuopai        bool                   `protobuf:"varint,3,opt,name=cuopai,proto3" json:"cuopai,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameLookData) Reset() {
+	*x = GameLookData{}
+	mi := &file_sz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameLookData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameLookData) ProtoMessage() {}
+
+func (x *GameLookData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameLookData.ProtoReflect.Descriptor instead.
+func (*GameLookData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GameLookData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GameLookData) GetCards() *roompb.IntList {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *GameLookData) GetCuopai() bool {
+	if x != nil {
+		return x.Cuopai
+	}
+	return false
+}
+
+type GameCompareData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromChairID   int32                  `protobuf:"varint,1,opt,name=fromChairID,proto3" json:"fromChairID,omitempty"`
+	ToChairID     int32                  `protobuf:"varint,2,opt,name=toChairID,proto3" json:"toChairID,omitempty"`
+	WinChairID    int32                  `protobuf:"varint,3,opt,name=winChairID,proto3" json:"winChairID,omitempty"`
+	LoseChairID   int32                  `protobuf:"varint,4,opt,name=loseChairID,proto3" json:"loseChairID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameCompareData) Reset() {
+	*x = GameCompareData{}
+	mi := &file_sz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameCompareData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameCompareData) ProtoMessage() {}
+
+func (x *GameCompareData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameCompareData.ProtoReflect.Descriptor instead.
+func (*GameCompareData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GameCompareData) GetFromChairID() int32 {
+	if x != nil {
+		return x.FromChairID
+	}
+	return 0
+}
+
+func (x *GameCompareData) GetToChairID() int32 {
+	if x != nil {
+		return x.ToChairID
+	}
+	return 0
+}
+
+func (x *GameCompareData) GetWinChairID() int32 {
+	if x != nil {
+		return x.WinChairID
+	}
+	return 0
+}
+
+func (x *GameCompareData) GetLoseChairID() int32 {
+	if x != nil {
+		return x.LoseChairID
+	}
+	return 0
+}
+
+type GameResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Winners       []int32                `protobuf:"varint,1,rep,packed,name=winners,proto3" json:"winners,omitempty"`
+	WinScores     []int32                `protobuf:"varint,2,rep,packed,name=winScores,proto3" json:"winScores,omitempty"`
+	HandCards     []*roompb.IntList      `protobuf:"bytes,3,rep,name=handCards,proto3" json:"handCards,omitempty"`
+	CurScores     []int32                `protobuf:"varint,4,rep,packed,name=curScores,proto3" json:"curScores,omitempty"`
+	Losers        []int32                `protobuf:"varint,5,rep,packed,name=losers,proto3" json:"losers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameResult) Reset() {
+	*x = GameResult{}
+	mi := &file_sz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResult) ProtoMessage() {}
+
+func (x *GameResult) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResult.ProtoReflect.Descriptor instead.
+func (*GameResult) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GameResult) GetWinners() []int32 {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+func (x *GameResult) GetWinScores() []int32 {
+	if x != nil {
+		return x.WinScores
+	}
+	return nil
+}
+
+func (x *GameResult) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *GameResult) GetCurScores() []int32 {
+	if x != nil {
+		return x.CurScores
+	}
+	return nil
+}
+
+func (x *GameResult) GetLosers() []int32 {
+	if x != nil {
+		return x.Losers
+	}
+	return nil
+}
+
+type GameResultData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *GameResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameResultData) Reset() {
+	*x = GameResultData{}
+	mi := &file_sz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResultData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResultData) ProtoMessage() {}
+
+func (x *GameResultData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResultData.ProtoReflect.Descriptor instead.
+func (*GameResultData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GameResultData) GetResult() *GameResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GameAbandonData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChairID       int32                  `protobuf:"varint,1,opt,name=chairID,proto3" json:"chairID,omitempty"`
+	UserStatus    int32                  `protobuf:"varint,2,opt,name=userStatus,proto3" json:"userStatus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameAbandonData) Reset() {
+	*x = GameAbandonData{}
+	mi := &file_sz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameAbandonData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameAbandonData) ProtoMessage() {}
+
+func (x *GameAbandonData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameAbandonData.ProtoReflect.Descriptor instead.
+func (*GameAbandonData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GameAbandonData) GetChairID() int32 {
+	if x != nil {
+		return x.ChairID
+	}
+	return 0
+}
+
+func (x *GameAbandonData) GetUserStatus() int32 {
+	if x != nil {
+		return x.UserStatus
+	}
+	return 0
+}
+
+type UserWinRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Avatar        string                 `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Score         int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserWinRecord) Reset() {
+	*x = UserWinRecord{}
+	mi := &file_sz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserWinRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserWinRecord) ProtoMessage() {}
+
+func (x *UserWinRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserWinRecord.ProtoReflect.Descriptor instead.
+func (*UserWinRecord) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UserWinRecord) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *UserWinRecord) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *UserWinRecord) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *UserWinRecord) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type BureauReview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Cards         []int32                `protobuf:"varint,2,rep,packed,name=cards,proto3" json:"cards,omitempty"`
+	PourScore     int32                  `protobuf:"varint,3,opt,name=pourScore,proto3" json:"pourScore,omitempty"`
+	WinScore      int32                  `protobuf:"varint,4,opt,name=winScore,proto3" json:"winScore,omitempty"`
+	NickName      string                 `protobuf:"bytes,5,opt,name=nickName,proto3" json:"nickName,omitempty"`
+	Avatar        string                 `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	IsBanker      bool                   `protobuf:"varint,7,opt,name=isBanker,proto3" json:"isBanker,omitempty"`
+	IsAbandon     bool                   `protobuf:"varint,8,opt,name=isAbandon,proto3" json:"isAbandon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BureauReview) Reset() {
+	*x = BureauReview{}
+	mi := &file_sz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BureauReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BureauReview) ProtoMessage() {}
+
+func (x *BureauReview) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BureauReview.ProtoReflect.Descriptor instead.
+func (*BureauReview) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BureauReview) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *BureauReview) GetCards() []int32 {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *BureauReview) GetPourScore() int32 {
+	if x != nil {
+		return x.PourScore
+	}
+	return 0
+}
+
+func (x *BureauReview) GetWinScore() int32 {
+	if x != nil {
+		return x.WinScore
+	}
+	return 0
+}
+
+func (x *BureauReview) GetNickName() string {
+	if x != nil {
+		return x.NickName
+	}
+	return ""
+}
+
+func (x *BureauReview) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *BureauReview) GetIsBanker() bool {
+	if x != nil {
+		return x.IsBanker
+	}
+	return false
+}
+
+func (x *BureauReview) GetIsAbandon() bool {
+	if x != nil {
+		return x.IsAbandon
+	}
+	return false
+}
+
+// GameData 断线重连、进入房间时的场景数据
+type GameData struct {
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	BankerChairID   int32                     `protobuf:"varint,1,opt,name=bankerChairID,proto3" json:"bankerChairID,omitempty"`
+	ChairCount      int32                     `protobuf:"varint,2,opt,name=chairCount,proto3" json:"chairCount,omitempty"`
+	CurBureau       int32                     `protobuf:"varint,3,opt,name=curBureau,proto3" json:"curBureau,omitempty"`
+	CurScore        int32                     `protobuf:"varint,4,opt,name=curScore,proto3" json:"curScore,omitempty"`
+	CurScores       []int32                   `protobuf:"varint,5,rep,packed,name=curScores,proto3" json:"curScores,omitempty"`
+	GameStarter     bool                      `protobuf:"varint,6,opt,name=gameStarter,proto3" json:"gameStarter,omitempty"`
+	GameStatus      int32                     `protobuf:"varint,7,opt,name=gameStatus,proto3" json:"gameStatus,omitempty"`
+	HandCards       []*roompb.IntList         `protobuf:"bytes,8,rep,name=handCards,proto3" json:"handCards,omitempty"`
+	LookCards       []int32                   `protobuf:"varint,9,rep,packed,name=lookCards,proto3" json:"lookCards,omitempty"`
+	Loser           []int32                   `protobuf:"varint,10,rep,packed,name=loser,proto3" json:"loser,omitempty"`
+	Winner          []int32                   `protobuf:"varint,11,rep,packed,name=winner,proto3" json:"winner,omitempty"`
+	MaxBureau       int32                     `protobuf:"varint,12,opt,name=maxBureau,proto3" json:"maxBureau,omitempty"`
+	PourScores      []*roompb.IntList         `protobuf:"bytes,13,rep,name=pourScores,proto3" json:"pourScores,omitempty"`
+	GameType        int32                     `protobuf:"varint,14,opt,name=gameType,proto3" json:"gameType,omitempty"`
+	BaseScore       int32                     `protobuf:"varint,15,opt,name=baseScore,proto3" json:"baseScore,omitempty"`
+	Result          *GameResult               `protobuf:"bytes,16,opt,name=result,proto3" json:"result,omitempty"`
+	Round           int32                     `protobuf:"varint,17,opt,name=round,proto3" json:"round,omitempty"`
+	Tick            int32                     `protobuf:"varint,18,opt,name=tick,proto3" json:"tick,omitempty"` //倒计时
+	UserTrustArray  []bool                    `protobuf:"varint,19,rep,packed,name=userTrustArray,proto3" json:"userTrustArray,omitempty"`
+	UserStatusArray []int32                   `protobuf:"varint,20,rep,packed,name=userStatusArray,proto3" json:"userStatusArray,omitempty"`
+	UserWinRecord   map[string]*UserWinRecord `protobuf:"bytes,21,rep,name=userWinRecord,proto3" json:"userWinRecord,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ReviewRecord    []*BureauReview           `protobuf:"bytes,22,rep,name=reviewRecord,proto3" json:"reviewRecord,omitempty"`
+	TrustTmArray    []int32                   `protobuf:"varint,23,rep,packed,name=trustTmArray,proto3" json:"trustTmArray,omitempty"`
+	CurChairID      int32                     `protobuf:"varint,24,opt,name=curChairID,proto3" json:"curChairID,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GameData) Reset() {
+	*x = GameData{}
+	mi := &file_sz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameData) ProtoMessage() {}
+
+func (x *GameData) ProtoReflect() protoreflect.Message {
+	mi := &file_sz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameData.ProtoReflect.Descriptor instead.
+func (*GameData) Descriptor() ([]byte, []int) {
+	return file_sz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GameData) GetBankerChairID() int32 {
+	if x != nil {
+		return x.BankerChairID
+	}
+	return 0
+}
+
+func (x *GameData) GetChairCount() int32 {
+	if x != nil {
+		return x.ChairCount
+	}
+	return 0
+}
+
+func (x *GameData) GetCurBureau() int32 {
+	if x != nil {
+		return x.CurBureau
+	}
+	return 0
+}
+
+func (x *GameData) GetCurScore() int32 {
+	if x != nil {
+		return x.CurScore
+	}
+	return 0
+}
+
+func (x *GameData) GetCurScores() []int32 {
+	if x != nil {
+		return x.CurScores
+	}
+	return nil
+}
+
+func (x *GameData) GetGameStarter() bool {
+	if x != nil {
+		return x.GameStarter
+	}
+	return false
+}
+
+func (x *GameData) GetGameStatus() int32 {
+	if x != nil {
+		return x.GameStatus
+	}
+	return 0
+}
+
+func (x *GameData) GetHandCards() []*roompb.IntList {
+	if x != nil {
+		return x.HandCards
+	}
+	return nil
+}
+
+func (x *GameData) GetLookCards() []int32 {
+	if x != nil {
+		return x.LookCards
+	}
+	return nil
+}
+
+func (x *GameData) GetLoser() []int32 {
+	if x != nil {
+		return x.Loser
+	}
+	return nil
+}
+
+func (x *GameData) GetWinner() []int32 {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *GameData) GetMaxBureau() int32 {
+	if x != nil {
+		return x.MaxBureau
+	}
+	return 0
+}
+
+func (x *GameData) GetPourScores() []*roompb.IntList {
+	if x != nil {
+		return x.PourScores
+	}
+	return nil
+}
+
+func (x *GameData) GetGameType() int32 {
+	if x != nil {
+		return x.GameType
+	}
+	return 0
+}
+
+func (x *GameData) GetBaseScore() int32 {
+	if x != nil {
+		return x.BaseScore
+	}
+	return 0
+}
+
+func (x *GameData) GetResult() *GameResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GameData) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *GameData) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameData) GetUserTrustArray() []bool {
+	if x != nil {
+		return x.UserTrustArray
+	}
+	return nil
+}
+
+func (x *GameData) GetUserStatusArray() []int32 {
+	if x != nil {
+		return x.UserStatusArray
+	}
+	return nil
+}
+
+func (x *GameData) GetUserWinRecord() map[string]*UserWinRecord {
+	if x != nil {
+		return x.UserWinRecord
+	}
+	return nil
+}
+
+func (x *GameData) GetReviewRecord() []*BureauReview {
+	if x != nil {
+		return x.ReviewRecord
+	}
+	return nil
+}
+
+func (x *GameData) GetTrustTmArray() []int32 {
+	if x != nil {
+		return x.TrustTmArray
+	}
+	return nil
+}
+
+func (x *GameData) GetCurChairID() int32 {
+	if x != nil {
+		return x.CurChairID
+	}
+	return 0
+}
+
+var File_sz_proto protoreflect.FileDescriptor
+
+const file_sz_proto_rawDesc = "" +
+	"\n" +
+	"\bsz.proto\x12\x02sz\x1a\n" +
+	"room.proto\"L\n" +
+	"\x16UpdateUserInfoGoldPush\x12\x12\n" +
+	"\x04gold\x18\x01 \x01(\x03R\x04gold\x12\x1e\n" +
+	"\n" +
+	"pushRouter\x18\x02 \x01(\tR\n" +
+	"pushRouter\"6\n" +
+	"\x0eGameBankerData\x12$\n" +
+	"\rbankerChairID\x18\x01 \x01(\x05R\rbankerChairID\".\n" +
+	"\x0eGameBureauData\x12\x1c\n" +
+	"\tcurBureau\x18\x01 \x01(\x05R\tcurBureau\"D\n" +
+	"\x0eGameStatusData\x12\x1e\n" +
+	"\n" +
+	"gameStatus\x18\x01 \x01(\x05R\n" +
+	"gameStatus\x12\x12\n" +
+	"\x04tick\x18\x02 \x01(\x05R\x04tick\"@\n" +
+	"\x11GameSendCardsData\x12+\n" +
+	"\thandCards\x18\x01 \x03(\v2\r.room.IntListR\thandCards\"\x8f\x01\n" +
+	"\x11GamePourScoreData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12\x1e\n" +
+	"\n" +
+	"chairScore\x18\x03 \x01(\x05R\n" +
+	"chairScore\x12\x16\n" +
+	"\x06scores\x18\x04 \x01(\x05R\x06scores\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\x05R\x04type\"%\n" +
+	"\rGameRoundData\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\"J\n" +
+	"\fGameTurnData\x12\x1e\n" +
+	"\n" +
+	"curChairID\x18\x01 \x01(\x05R\n" +
+	"curChairID\x12\x1a\n" +
+	"\bcurScore\x18\x02 \x01(\x05R\bcurScore\"e\n" +
+	"\fGameLookData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12#\n" +
+	"\x05cards\x18\x02 \x01(\v2\r.room.IntListR\x05cards\x12\x16\n" +
+	"\x06cuopai\x18\x03 \x01(\bR\x06cuopai\"\x93\x01\n" +
+	"\x0fGameCompareData\x12 \n" +
+	"\vfromChairID\x18\x01 \x01(\x05R\vfromChairID\x12\x1c\n" +
+	"\ttoChairID\x18\x02 \x01(\x05R\ttoChairID\x12\x1e\n" +
+	"\n" +
+	"winChairID\x18\x03 \x01(\x05R\n" +
+	"winChairID\x12 \n" +
+	"\vloseChairID\x18\x04 \x01(\x05R\vloseChairID\"\xa7\x01\n" +
+	"\n" +
+	"GameResult\x12\x18\n" +
+	"\awinners\x18\x01 \x03(\x05R\awinners\x12\x1c\n" +
+	"\twinScores\x18\x02 \x03(\x05R\twinScores\x12+\n" +
+	"\thandCards\x18\x03 \x03(\v2\r.room.IntListR\thandCards\x12\x1c\n" +
+	"\tcurScores\x18\x04 \x03(\x05R\tcurScores\x12\x16\n" +
+	"\x06losers\x18\x05 \x03(\x05R\x06losers\"8\n" +
+	"\x0eGameResultData\x12&\n" +
+	"\x06result\x18\x01 \x01(\v2\x0e.sz.GameResultR\x06result\"K\n" +
+	"\x0fGameAbandonData\x12\x18\n" +
+	"\achairID\x18\x01 \x01(\x05R\achairID\x12\x1e\n" +
+	"\n" +
+	"userStatus\x18\x02 \x01(\x05R\n" +
+	"userStatus\"k\n" +
+	"\rUserWinRecord\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\"\xde\x01\n" +
+	"\fBureauReview\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x14\n" +
+	"\x05cards\x18\x02 \x03(\x05R\x05cards\x12\x1c\n" +
+	"\tpourScore\x18\x03 \x01(\x05R\tpourScore\x12\x1a\n" +
+	"\bwinScore\x18\x04 \x01(\x05R\bwinScore\x12\x1a\n" +
+	"\bnickName\x18\x05 \x01(\tR\bnickName\x12\x16\n" +
+	"\x06avatar\x18\x06 \x01(\tR\x06avatar\x12\x1a\n" +
+	"\bisBanker\x18\a \x01(\bR\bisBanker\x12\x1c\n" +
+	"\tisAbandon\x18\b \x01(\bR\tisAbandon\"\xa4\a\n" +
+	"\bGameData\x12$\n" +
+	"\rbankerChairID\x18\x01 \x01(\x05R\rbankerChairID\x12\x1e\n" +
+	"\n" +
+	"chairCount\x18\x02 \x01(\x05R\n" +
+	"chairCount\x12\x1c\n" +
+	"\tcurBureau\x18\x03 \x01(\x05R\tcurBureau\x12\x1a\n" +
+	"\bcurScore\x18\x04 \x01(\x05R\bcurScore\x12\x1c\n" +
+	"\tcurScores\x18\x05 \x03(\x05R\tcurScores\x12 \n" +
+	"\vgameStarter\x18\x06 \x01(\bR\vgameStarter\x12\x1e\n" +
+	"\n" +
+	"gameStatus\x18\a \x01(\x05R\n" +
+	"gameStatus\x12+\n" +
+	"\thandCards\x18\b \x03(\v2\r.room.IntListR\thandCards\x12\x1c\n" +
+	"\tlookCards\x18\t \x03(\x05R\tlookCards\x12\x14\n" +
+	"\x05loser\x18\n" +
+	" \x03(\x05R\x05loser\x12\x16\n" +
+	"\x06winner\x18\v \x03(\x05R\x06winner\x12\x1c\n" +
+	"\tmaxBureau\x18\f \x01(\x05R\tmaxBureau\x12-\n" +
+	"\n" +
+	"pourScores\x18\r \x03(\v2\r.room.IntListR\n" +
+	"pourScores\x12\x1a\n" +
+	"\bgameType\x18\x0e \x01(\x05R\bgameType\x12\x1c\n" +
+	"\tbaseScore\x18\x0f \x01(\x05R\tbaseScore\x12&\n" +
+	"\x06result\x18\x10 \x01(\v2\x0e.sz.GameResultR\x06result\x12\x14\n" +
+	"\x05round\x18\x11 \x01(\x05R\x05round\x12\x12\n" +
+	"\x04tick\x18\x12 \x01(\x05R\x04tick\x12&\n" +
+	"\x0euserTrustArray\x18\x13 \x03(\bR\x0euserTrustArray\x12(\n" +
+	"\x0fuserStatusArray\x18\x14 \x03(\x05R\x0fuserStatusArray\x12E\n" +
+	"\ruserWinRecord\x18\x15 \x03(\v2\x1f.sz.GameData.UserWinRecordEntryR\ruserWinRecord\x124\n" +
+	"\freviewRecord\x18\x16 \x03(\v2\x10.sz.BureauReviewR\freviewRecord\x12\"\n" +
+	"\ftrustTmArray\x18\x17 \x03(\x05R\ftrustTmArray\x12\x1e\n" +
+	"\n" +
+	"curChairID\x18\x18 \x01(\x05R\n" +
+	"curChairID\x1aS\n" +
+	"\x12UserWinRecordEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.sz.UserWinRecordR\x05value:\x028\x01B\x13Z\x11game/pb/szpb;szpbb\x06proto3"
+
+var (
+	file_sz_proto_rawDescOnce sync.Once
+	file_sz_proto_rawDescData []byte
+)
+
+func file_sz_proto_rawDescGZIP() []byte {
+	file_sz_proto_rawDescOnce.Do(func() {
+		file_sz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sz_proto_rawDesc), len(file_sz_proto_rawDesc)))
+	})
+	return file_sz_proto_rawDescData
+}
+
+var file_sz_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_sz_proto_goTypes = []any{
+	(*UpdateUserInfoGoldPush)(nil), // 0: sz.UpdateUserInfoGoldPush
+	(*GameBankerData)(nil),         // 1: sz.GameBankerData
+	(*GameBureauData)(nil),         // 2: sz.GameBureauData
+	(*GameStatusData)(nil),         // 3: sz.GameStatusData
+	(*GameSendCardsData)(nil),      // 4: sz.GameSendCardsData
+	(*GamePourScoreData)(nil),      // 5: sz.GamePourScoreData
+	(*GameRoundData)(nil),          // 6: sz.GameRoundData
+	(*GameTurnData)(nil),           // 7: sz.GameTurnData
+	(*GameLookData)(nil),           // 8: sz.GameLookData
+	(*GameCompareData)(nil),        // 9: sz.GameCompareData
+	(*GameResult)(nil),             // 10: sz.GameResult
+	(*GameResultData)(nil),         // 11: sz.GameResultData
+	(*GameAbandonData)(nil),        // 12: sz.GameAbandonData
+	(*UserWinRecord)(nil),          // 13: sz.UserWinRecord
+	(*BureauReview)(nil),           // 14: sz.BureauReview
+	(*GameData)(nil),               // 15: sz.GameData
+	nil,                            // 16: sz.GameData.UserWinRecordEntry
+	(*roompb.IntList)(nil),         // 17: room.IntList
+}
+var file_sz_proto_depIdxs = []int32{
+	17, // 0: sz.GameSendCardsData.handCards:type_name -> room.IntList
+	17, // 1: sz.GameLookData.cards:type_name -> room.IntList
+	17, // 2: sz.GameResult.handCards:type_name -> room.IntList
+	10, // 3: sz.GameResultData.result:type_name -> sz.GameResult
+	17, // 4: sz.GameData.handCards:type_name -> room.IntList
+	17, // 5: sz.GameData.pourScores:type_name -> room.IntList
+	10, // 6: sz.GameData.result:type_name -> sz.GameResult
+	16, // 7: sz.GameData.userWinRecord:type_name -> sz.GameData.UserWinRecordEntry
+	14, // 8: sz.GameData.reviewRecord:type_name -> sz.BureauReview
+	13, // 9: sz.GameData.UserWinRecordEntry.value:type_name -> sz.UserWinRecord
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_sz_proto_init() }
+func file_sz_proto_init() {
+	if File_sz_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sz_proto_rawDesc), len(file_sz_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sz_proto_goTypes,
+		DependencyIndexes: file_sz_proto_depIdxs,
+		MessageInfos:      file_sz_proto_msgTypes,
+	}.Build()
+	File_sz_proto = out.File
+	file_sz_proto_goTypes = nil
+	file_sz_proto_depIdxs = nil
+}
@@ -1,20 +1,29 @@
 package route
 
 import (
+	"common/biz"
 	"core/repo"
+	"framework/directory"
 	"framework/node"
 	"game/handler"
 	"game/logic"
 )
 
-func Register(r *repo.Manager) node.LogicHandler {
+func Register(r *repo.Manager, serverId string) node.LogicHandler {
 	handlers := make(node.LogicHandler)
-	manager := logic.NewUnionManager()
-	unionHandler := handler.NewUnionHandler(r, manager)
-	handlers["unionHandler.createRoom"] = unionHandler.CreateRoom
-	handlers["unionHandler.joinRoom"] = unionHandler.JoinRoom
-	gameHandler := handler.NewGameHandler(r, manager)
-	handlers["gameHandler.roomMessageNotify"] = gameHandler.RoomMessageNotify
-	handlers["gameHandler.gameMessageNotify"] = gameHandler.GameMessageNotify
+	manager := logic.NewUnionManager(directory.NewRedisRoomDirectory(r.Redis), serverId)
+	// game 的路由都需要先登录
+	auth := node.Auth(biz.InvalidUsers)
+	// unionHandler.createRoom unionHandler.joinRoom
+	node.RegisterMethods(handlers, "unionHandler", handler.NewUnionHandler(r, manager), auth)
+	// gameHandler.roomMessageNotify gameHandler.gameMessageNotify
+	node.RegisterMethods(handlers, "gameHandler", handler.NewGameHandler(r, manager), auth)
 	return handlers
 }
+
+// RoomRoutes 房间号在请求体中的路由和字段，node 按房间号串行执行，和 connector 的 RoomRoutes 对应
+func RoomRoutes() map[string]string {
+	return map[string]string{
+		"unionHandler.joinRoom": "roomID",
+	}
+}
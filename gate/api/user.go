@@ -8,6 +8,7 @@ import (
 	"common/logs"
 	"common/rpc"
 	"context"
+	"framework/game"
 	"framework/msError"
 	"time"
 	"user/pb"
@@ -58,12 +59,23 @@ func (h UserHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	connector := config.Conf.Services["connector"]
 	result := map[string]any{
 		"token": token,
 		"serverInfo": map[string]any{
-			"host": config.Conf.Services["connector"].ClientHost,
-			"port": config.Conf.Services["connector"].ClientPort,
+			"scheme": connectorScheme(connector.Id),
+			"host":   connector.ClientHost,
+			"port":   connector.ClientPort,
 		},
 	}
 	common.Success(ctx, result)
 }
+
+// connectorScheme 按 connector 的证书配置决定客户端用 ws 还是 wss，servers.json 中找不到时按 ws
+func connectorScheme(serverId string) string {
+	if conf := game.Conf.GetConnector(serverId); conf != nil {
+		return conf.Scheme()
+	}
+	logs.Warn("connector %s not found in servers config, use ws", serverId)
+	return "ws"
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"framework/game"
 	"gate/app"
 	"log"
 	"os"
@@ -13,10 +14,14 @@ import (
 
 var configFile = flag.String("config", "application.yml", "config file")
 
+// connector 的证书配置在 servers.json 中，gate 按它下发 ws 或 wss
+var gameConfigDir = flag.String("gameDir", "../config", "game config dir")
+
 func main() {
 	// 1.加载配置
 	flag.Parse()
 	config.InnitConfig(*configFile)
+	game.InitConfig(*gameConfigDir)
 	fmt.Println(config.Conf)
 	// 2.启动监控
 	go func() {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"core/repo"
 	"fmt"
+	"framework/directory"
 	"framework/node"
 	"hall/route"
 	"os"
@@ -25,7 +26,10 @@ func Run(ctx context.Context, serverId string) error {
 		exit = n.Close
 		// 初始化数据库
 		manager := repo.New()
-		// 注册路由
+		// 用户在线目录，推送到其他 connector 上的用户
+		n.SetPresence(directory.NewRedisPresence(manager.Redis))
+		// 注册路由，所有路由都经过 panic 恢复、统计和日志
+		n.Use(node.Recover(), node.Metrics(), node.Logger())
 		n.RegisterHandler(route.Register(manager))
 		n.Run(serverId)
 	}()
@@ -6,7 +6,7 @@ import (
 	"common/logs"
 	"core/repo"
 	"core/service"
-	"encoding/json"
+	"framework/msError"
 	"framework/remote"
 	"hall/model/request"
 	"hall/model/response"
@@ -16,24 +16,19 @@ type UserHandler struct {
 	userService *service.UserService
 }
 
-func (u UserHandler) UpdateUserAddress(session *remote.Session, msg []byte) any {
-	logs.Info("UpdateUserAddress msg: %v", string(msg))
-	var req request.UpdateUserAddressReq
-	err := json.Unmarshal(msg, &req)
+func (u UserHandler) UpdateUserAddress(session *remote.Session, req *request.UpdateUserAddressReq) (*response.UpdateUserAddressResp, *msError.Error) {
+	logs.Info("UpdateUserAddress req: %+v", *req)
+	err := u.userService.UpdateUserAddressByUid(session.GetUid(), *req)
 	if err != nil {
-		return common.FailNoCtx(biz.RequestDataError)
-	}
-	err = u.userService.UpdateUserAddressByUid(session.GetUid(), req)
-	if err != nil {
-		return common.FailNoCtx(biz.SqlError)
+		return nil, biz.SqlError
 	}
 	resp := response.UpdateUserAddressResp{
 		Res: common.Result{
 			Code: biz.OK,
 		},
-		UpdateUserData: req,
+		UpdateUserData: *req,
 	}
-	return resp
+	return &resp, nil
 }
 
 func NewUserHandler(r *repo.Manager) *UserHandler {
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"common/biz"
 	"core/repo"
 	"framework/node"
 	"hall/handler"
@@ -8,7 +9,7 @@ import (
 
 func Register(r *repo.Manager) node.LogicHandler {
 	handlers := make(node.LogicHandler)
-	userHandler := handler.NewUserHandler(r)
-	handlers["userHandler.updateUserAddress"] = userHandler.UpdateUserAddress
+	// userHandler.updateUserAddress
+	node.RegisterMethods(handlers, "userHandler", handler.NewUserHandler(r), node.Auth(biz.InvalidUsers))
 	return handlers
 }